@@ -0,0 +1,783 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+// At methods read and write values at an absolute offset of the buffer.
+// They neither move the offset nor record an error of the stream.
+
+// at returns n bytes at off of the buffer, or returns a DecodeError if they are out of the buffer
+func (bs *Stream) at(typ string, off int, n int) ([]byte, error) {
+	if off < 0 || n < 0 {
+		return nil, &DecodeError{Off: off, Type: typ, Need: n, Err: ErrInvalidOffset}
+	}
+
+	if off > len(bs.buf) || n > len(bs.buf)-off {
+		avail := 0
+		if off < len(bs.buf) {
+			avail = len(bs.buf) - off
+		}
+
+		return nil, &DecodeError{Off: off, Type: typ, Need: n, Avail: avail, Err: ErrNotEnought}
+	}
+
+	return bs.buf[off : off+n], nil
+}
+
+// putAt returns n bytes at off of the buffer to overwrite, or returns ErrInvalidOffset if they are out of the buffer
+// Values are appended to b[:0] and copied to b, so they are written even if an Order allocates new bytes.
+func (bs *Stream) putAt(off int, n int) ([]byte, error) {
+	if off < 0 || n < 0 || off > len(bs.buf) || n > len(bs.buf)-off {
+		return nil, ErrInvalidOffset
+	}
+
+	return bs.buf[off : off+n : off+n], nil
+}
+
+// BytesAt gets n bytes at off
+// The returned bytes share the buffer
+func (bs *Stream) BytesAt(off int, n int) ([]byte, error) {
+	return bs.at("Bytes", off, n)
+}
+
+// PutBytesAt overwrites bytes at off with value
+func (bs *Stream) PutBytesAt(off int, value []byte) error {
+	b, err := bs.putAt(off, len(value))
+	if err != nil {
+		return err
+	}
+
+	copy(b, value)
+
+	return nil
+}
+
+// ByteAt gets an unsigned byte at off
+func (bs *Stream) ByteAt(off int) (byte, error) {
+	b, err := bs.at("Byte", off, ByteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadByte(b), nil
+}
+
+// PutByteAt puts an unsigned byte at off
+func (bs *Stream) PutByteAt(off int, value byte) error {
+	b, err := bs.putAt(off, ByteSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendByte(b[:0], value))
+
+	return nil
+}
+
+// SByteAt gets a signed byte at off
+func (bs *Stream) SByteAt(off int) (int8, error) {
+	b, err := bs.at("SByte", off, ByteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadSByte(b), nil
+}
+
+// PutSByteAt puts a signed byte at off
+func (bs *Stream) PutSByteAt(off int, value int8) error {
+	b, err := bs.putAt(off, ByteSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendSByte(b[:0], value))
+
+	return nil
+}
+
+// ShortAt gets an unsigned short at off
+func (bs *Stream) ShortAt(off int) (uint16, error) {
+	b, err := bs.at("Short", off, ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUShort(b), nil
+}
+
+// PutShortAt puts an unsigned short at off
+func (bs *Stream) PutShortAt(off int, value uint16) error {
+	b, err := bs.putAt(off, ShortSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendUShort(b[:0], value))
+
+	return nil
+}
+
+// SShortAt gets a signed short at off
+func (bs *Stream) SShortAt(off int) (int16, error) {
+	b, err := bs.at("SShort", off, ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadShort(b), nil
+}
+
+// PutSShortAt puts a signed short at off
+func (bs *Stream) PutSShortAt(off int, value int16) error {
+	b, err := bs.putAt(off, ShortSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendShort(b[:0], value))
+
+	return nil
+}
+
+// LShortAt gets an unsigned short with LittleEndian at off
+func (bs *Stream) LShortAt(off int) (uint16, error) {
+	b, err := bs.at("LShort", off, ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUShort(b), nil
+}
+
+// PutLShortAt puts an unsigned short with LittleEndian at off
+func (bs *Stream) PutLShortAt(off int, value uint16) error {
+	b, err := bs.putAt(off, ShortSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLUShort(b[:0], value))
+
+	return nil
+}
+
+// LSShortAt gets a signed short with LittleEndian at off
+func (bs *Stream) LSShortAt(off int) (int16, error) {
+	b, err := bs.at("LSShort", off, ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLShort(b), nil
+}
+
+// PutLSShortAt puts a signed short with LittleEndian at off
+func (bs *Stream) PutLSShortAt(off int, value int16) error {
+	b, err := bs.putAt(off, ShortSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLShort(b[:0], value))
+
+	return nil
+}
+
+// TriadAt gets a signed triad at off
+func (bs *Stream) TriadAt(off int) (int32, error) {
+	b, err := bs.at("Triad", off, TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadTriad(b), nil
+}
+
+// PutTriadAt puts a signed triad at off
+func (bs *Stream) PutTriadAt(off int, value int32) error {
+	b, err := bs.putAt(off, TriadSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendTriad(b[:0], value))
+
+	return nil
+}
+
+// UTriadAt gets an unsigned triad at off
+func (bs *Stream) UTriadAt(off int) (uint32, error) {
+	b, err := bs.at("UTriad", off, TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUTriad(b), nil
+}
+
+// PutUTriadAt puts an unsigned triad at off
+func (bs *Stream) PutUTriadAt(off int, value uint32) error {
+	b, err := bs.putAt(off, TriadSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendUTriad(b[:0], value))
+
+	return nil
+}
+
+// LTriadAt gets a signed triad with LittleEndian at off
+func (bs *Stream) LTriadAt(off int) (int32, error) {
+	b, err := bs.at("LTriad", off, TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLTriad(b), nil
+}
+
+// PutLTriadAt puts a signed triad with LittleEndian at off
+func (bs *Stream) PutLTriadAt(off int, value int32) error {
+	b, err := bs.putAt(off, TriadSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLTriad(b[:0], value))
+
+	return nil
+}
+
+// LUTriadAt gets an unsigned triad with LittleEndian at off
+func (bs *Stream) LUTriadAt(off int) (uint32, error) {
+	b, err := bs.at("LUTriad", off, TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUTriad(b), nil
+}
+
+// PutLUTriadAt puts an unsigned triad with LittleEndian at off
+func (bs *Stream) PutLUTriadAt(off int, value uint32) error {
+	b, err := bs.putAt(off, TriadSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLUTriad(b[:0], value))
+
+	return nil
+}
+
+// IntAt gets a signed int at off
+func (bs *Stream) IntAt(off int) (int32, error) {
+	b, err := bs.at("Int", off, IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadInt(b), nil
+}
+
+// PutIntAt puts a signed int at off
+func (bs *Stream) PutIntAt(off int, value int32) error {
+	b, err := bs.putAt(off, IntSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendInt(b[:0], value))
+
+	return nil
+}
+
+// UIntAt gets an unsigned int at off
+func (bs *Stream) UIntAt(off int) (uint32, error) {
+	b, err := bs.at("UInt", off, IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUInt(b), nil
+}
+
+// PutUIntAt puts an unsigned int at off
+func (bs *Stream) PutUIntAt(off int, value uint32) error {
+	b, err := bs.putAt(off, IntSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendUInt(b[:0], value))
+
+	return nil
+}
+
+// LIntAt gets a signed int with LittleEndian at off
+func (bs *Stream) LIntAt(off int) (int32, error) {
+	b, err := bs.at("LInt", off, IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLInt(b), nil
+}
+
+// PutLIntAt puts a signed int with LittleEndian at off
+func (bs *Stream) PutLIntAt(off int, value int32) error {
+	b, err := bs.putAt(off, IntSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLInt(b[:0], value))
+
+	return nil
+}
+
+// LUIntAt gets an unsigned int with LittleEndian at off
+func (bs *Stream) LUIntAt(off int) (uint32, error) {
+	b, err := bs.at("LUInt", off, IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUInt(b), nil
+}
+
+// PutLUIntAt puts an unsigned int with LittleEndian at off
+func (bs *Stream) PutLUIntAt(off int, value uint32) error {
+	b, err := bs.putAt(off, IntSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLUInt(b[:0], value))
+
+	return nil
+}
+
+// LongAt gets a signed long at off
+func (bs *Stream) LongAt(off int) (int64, error) {
+	b, err := bs.at("Long", off, LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLong(b), nil
+}
+
+// PutLongAt puts a signed long at off
+func (bs *Stream) PutLongAt(off int, value int64) error {
+	b, err := bs.putAt(off, LongSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLong(b[:0], value))
+
+	return nil
+}
+
+// LLongAt gets a signed long with LittleEndian at off
+func (bs *Stream) LLongAt(off int) (int64, error) {
+	b, err := bs.at("LLong", off, LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLLong(b), nil
+}
+
+// PutLLongAt puts a signed long with LittleEndian at off
+func (bs *Stream) PutLLongAt(off int, value int64) error {
+	b, err := bs.putAt(off, LongSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLLong(b[:0], value))
+
+	return nil
+}
+
+// ULongAt gets an unsigned long at off
+func (bs *Stream) ULongAt(off int) (uint64, error) {
+	b, err := bs.at("ULong", off, LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadULong(b), nil
+}
+
+// PutULongAt puts an unsigned long at off
+func (bs *Stream) PutULongAt(off int, value uint64) error {
+	b, err := bs.putAt(off, LongSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendULong(b[:0], value))
+
+	return nil
+}
+
+// LULongAt gets an unsigned long with LittleEndian at off
+func (bs *Stream) LULongAt(off int) (uint64, error) {
+	b, err := bs.at("LULong", off, LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLULong(b), nil
+}
+
+// PutLULongAt puts an unsigned long with LittleEndian at off
+func (bs *Stream) PutLULongAt(off int, value uint64) error {
+	b, err := bs.putAt(off, LongSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLULong(b[:0], value))
+
+	return nil
+}
+
+// FloatAt gets a float at off
+func (bs *Stream) FloatAt(off int) (float32, error) {
+	b, err := bs.at("Float", off, FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadFloat(b), nil
+}
+
+// PutFloatAt puts a float at off
+func (bs *Stream) PutFloatAt(off int, value float32) error {
+	b, err := bs.putAt(off, FloatSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendFloat(b[:0], value))
+
+	return nil
+}
+
+// LFloatAt gets a float with LittleEndian at off
+func (bs *Stream) LFloatAt(off int) (float32, error) {
+	b, err := bs.at("LFloat", off, FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLFloat(b), nil
+}
+
+// PutLFloatAt puts a float with LittleEndian at off
+func (bs *Stream) PutLFloatAt(off int, value float32) error {
+	b, err := bs.putAt(off, FloatSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLFloat(b[:0], value))
+
+	return nil
+}
+
+// DoubleAt gets a double at off
+func (bs *Stream) DoubleAt(off int) (float64, error) {
+	b, err := bs.at("Double", off, DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadDouble(b), nil
+}
+
+// PutDoubleAt puts a double at off
+func (bs *Stream) PutDoubleAt(off int, value float64) error {
+	b, err := bs.putAt(off, DoubleSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendDouble(b[:0], value))
+
+	return nil
+}
+
+// LDoubleAt gets a double with LittleEndian at off
+func (bs *Stream) LDoubleAt(off int) (float64, error) {
+	b, err := bs.at("LDouble", off, DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLDouble(b), nil
+}
+
+// PutLDoubleAt puts a double with LittleEndian at off
+func (bs *Stream) PutLDoubleAt(off int, value float64) error {
+	b, err := bs.putAt(off, DoubleSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, AppendLDouble(b[:0], value))
+
+	return nil
+}
+
+// BoolAt gets a byte at off and returns as bool
+func (bs *Stream) BoolAt(off int) (bool, error) {
+	val, err := bs.ByteAt(off)
+	if err != nil {
+		return false, err
+	}
+
+	return val != 0, nil
+}
+
+// PutBoolAt puts a bool as a byte at off
+func (bs *Stream) PutBoolAt(off int, value bool) error {
+	var val byte
+	if value {
+		val = 1 // true
+	}
+
+	return bs.PutByteAt(off, val)
+}
+
+// ShortAt gets an unsigned short at off with the order
+func (bs *OrderStream) ShortAt(off int) (uint16, error) {
+	b, err := bs.at("Short", off, ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.UShort(b), nil
+}
+
+// PutShortAt puts an unsigned short at off with the order
+func (bs *OrderStream) PutShortAt(off int, value uint16) error {
+	b, err := bs.putAt(off, ShortSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendUShort(b[:0], value))
+
+	return nil
+}
+
+// SShortAt gets a signed short at off with the order
+func (bs *OrderStream) SShortAt(off int) (int16, error) {
+	b, err := bs.at("SShort", off, ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Short(b), nil
+}
+
+// PutSShortAt puts a signed short at off with the order
+func (bs *OrderStream) PutSShortAt(off int, value int16) error {
+	b, err := bs.putAt(off, ShortSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendShort(b[:0], value))
+
+	return nil
+}
+
+// TriadAt gets a signed triad at off with the order
+func (bs *OrderStream) TriadAt(off int) (int32, error) {
+	b, err := bs.at("Triad", off, TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Triad(b), nil
+}
+
+// PutTriadAt puts a signed triad at off with the order
+func (bs *OrderStream) PutTriadAt(off int, value int32) error {
+	b, err := bs.putAt(off, TriadSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendTriad(b[:0], value))
+
+	return nil
+}
+
+// UTriadAt gets an unsigned triad at off with the order
+func (bs *OrderStream) UTriadAt(off int) (uint32, error) {
+	b, err := bs.at("UTriad", off, TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.UTriad(b), nil
+}
+
+// PutUTriadAt puts an unsigned triad at off with the order
+func (bs *OrderStream) PutUTriadAt(off int, value uint32) error {
+	b, err := bs.putAt(off, TriadSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendUTriad(b[:0], value))
+
+	return nil
+}
+
+// IntAt gets a signed int at off with the order
+func (bs *OrderStream) IntAt(off int) (int32, error) {
+	b, err := bs.at("Int", off, IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Int(b), nil
+}
+
+// PutIntAt puts a signed int at off with the order
+func (bs *OrderStream) PutIntAt(off int, value int32) error {
+	b, err := bs.putAt(off, IntSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendInt(b[:0], value))
+
+	return nil
+}
+
+// UIntAt gets an unsigned int at off with the order
+func (bs *OrderStream) UIntAt(off int) (uint32, error) {
+	b, err := bs.at("UInt", off, IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.UInt(b), nil
+}
+
+// PutUIntAt puts an unsigned int at off with the order
+func (bs *OrderStream) PutUIntAt(off int, value uint32) error {
+	b, err := bs.putAt(off, IntSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendUInt(b[:0], value))
+
+	return nil
+}
+
+// LongAt gets a signed long at off with the order
+func (bs *OrderStream) LongAt(off int) (int64, error) {
+	b, err := bs.at("Long", off, LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Long(b), nil
+}
+
+// PutLongAt puts a signed long at off with the order
+func (bs *OrderStream) PutLongAt(off int, value int64) error {
+	b, err := bs.putAt(off, LongSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendLong(b[:0], value))
+
+	return nil
+}
+
+// ULongAt gets an unsigned long at off with the order
+func (bs *OrderStream) ULongAt(off int) (uint64, error) {
+	b, err := bs.at("ULong", off, LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.ULong(b), nil
+}
+
+// PutULongAt puts an unsigned long at off with the order
+func (bs *OrderStream) PutULongAt(off int, value uint64) error {
+	b, err := bs.putAt(off, LongSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendULong(b[:0], value))
+
+	return nil
+}
+
+// FloatAt gets a float at off with the order
+func (bs *OrderStream) FloatAt(off int) (float32, error) {
+	b, err := bs.at("Float", off, FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Float(b), nil
+}
+
+// PutFloatAt puts a float at off with the order
+func (bs *OrderStream) PutFloatAt(off int, value float32) error {
+	b, err := bs.putAt(off, FloatSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendFloat(b[:0], value))
+
+	return nil
+}
+
+// DoubleAt gets a double at off with the order
+func (bs *OrderStream) DoubleAt(off int) (float64, error) {
+	b, err := bs.at("Double", off, DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Double(b), nil
+}
+
+// PutDoubleAt puts a double at off with the order
+func (bs *OrderStream) PutDoubleAt(off int, value float64) error {
+	b, err := bs.putAt(off, DoubleSize)
+	if err != nil {
+		return err
+	}
+
+	copy(b, bs.Order.AppendDouble(b[:0], value))
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStreamAt(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07})
+	stream.Skip(1)
+
+	val, err := stream.IntAt(2)
+	if err != nil {
+		t.Fatalf("Failed to get int Error: %s", err)
+	}
+
+	if val != 0x02030405 {
+		t.Fatalf("Expected %x for int, but %x", 0x02030405, val)
+	}
+
+	lval, _ := stream.LShortAt(6)
+	if lval != 0x0706 {
+		t.Fatalf("Expected %x for short, but %x", 0x0706, lval)
+	}
+
+	err = stream.PutLFloatAt(4, 1.5)
+	if err != nil {
+		t.Fatalf("Failed to put float Error: %s", err)
+	}
+
+	fval, _ := stream.LFloatAt(4)
+	if fval != 1.5 {
+		t.Fatalf("Expected %f for float, but %f", 1.5, fval)
+	}
+
+	exp := []byte{0x00, 0x01, 0x02, 0x03, 0x00, 0x00, 0xc0, 0x3f}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	if stream.Off() != 1 {
+		t.Fatalf("Expected %d for offset, but %d", 1, stream.Off())
+	}
+}
+
+func TestStreamAtError(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x00, 0x01, 0x02})
+
+	_, err := stream.ShortAt(2)
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("Expected a DecodeError, but %v", err)
+	}
+
+	exp := DecodeError{Off: 2, Type: "Short", Need: ShortSize, Avail: 1, Err: ErrNotEnought}
+	if *derr != exp {
+		t.Fatalf("Expected %+v for error, but %+v", exp, *derr)
+	}
+
+	_, err = stream.LongAt(100)
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+
+	_, err = stream.ByteAt(-1)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidOffset, err)
+	}
+
+	err = stream.PutUTriadAt(1, 0)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidOffset, err)
+	}
+
+	_, err = stream.BytesAt(1, -1)
+	if !errors.As(err, &derr) || !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected a DecodeError with %v, but %v", ErrInvalidOffset, err)
+	}
+
+	err = stream.PutBytesAt(1, nil)
+	if err != nil {
+		t.Fatalf("Failed to put empty bytes Error: %s", err)
+	}
+
+	// At methods don't record the error
+	if stream.Err() != nil {
+		t.Fatalf("Expected no error, but %v", stream.Err())
+	}
+}
+
+func TestOrderStreamAt(t *testing.T) {
+	stream := NewOrderStream(LittleEndian)
+	stream.Pad(8)
+
+	err := stream.PutUIntAt(2, 0x01020304)
+	if err != nil {
+		t.Fatalf("Failed to put int Error: %s", err)
+	}
+
+	exp := []byte{0x00, 0x00, 0x04, 0x03, 0x02, 0x01, 0x00, 0x00}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	val, _ := stream.UIntAt(2)
+	if val != 0x01020304 {
+		t.Fatalf("Expected %x for int, but %x", 0x01020304, val)
+	}
+
+	_, err = stream.DoubleAt(1)
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+}
+
+// allocOrder is an Order whose Append methods always allocate new bytes
+type allocOrder struct {
+	Order
+}
+
+func (o allocOrder) AppendUShort(dst []byte, v uint16) []byte {
+	return o.Order.AppendUShort(append([]byte(nil), dst...), v)
+}
+
+func (o allocOrder) AppendLong(dst []byte, v int64) []byte {
+	return o.Order.AppendLong(append([]byte(nil), dst...), v)
+}
+
+func TestOrderStreamPutAtAllocOrder(t *testing.T) {
+	stream := NewOrderStream(allocOrder{BigEndian})
+	stream.Pad(10)
+
+	if err := stream.PutShortAt(0, 0x0102); err != nil {
+		t.Fatalf("Failed to put short Error: %s", err)
+	}
+
+	if err := stream.PutLongAt(2, 0x0304050607080910); err != nil {
+		t.Fatalf("Failed to put long Error: %s", err)
+	}
+
+	exp := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"io"
 	"math"
+	"reflect"
 )
 
 const (
@@ -22,6 +23,9 @@ const (
 	// ShortSize is byte size of Short
 	ShortSize = 2
 
+	// TriadSize is byte size of Triad
+	TriadSize = 3
+
 	// IntSize is byte size of Int
 	IntSize = 4
 
@@ -105,6 +109,46 @@ func WriteLUShort(v uint16) []byte {
 	}
 }
 
+func ReadTriad(v []byte) int32 {
+	return int32(ReadUTriad(v)<<8) >> 8
+}
+
+func WriteTriad(v int32) []byte {
+	return WriteUTriad(uint32(v))
+}
+
+func ReadUTriad(v []byte) uint32 {
+	return uint32(v[0])<<16 | uint32(v[1])<<8 | uint32(v[2])
+}
+
+func WriteUTriad(v uint32) []byte {
+	return []byte{
+		byte(v >> 16),
+		byte(v >> 8),
+		byte(v),
+	}
+}
+
+func ReadLTriad(v []byte) int32 {
+	return int32(ReadLUTriad(v)<<8) >> 8
+}
+
+func WriteLTriad(v int32) []byte {
+	return WriteLUTriad(uint32(v))
+}
+
+func ReadLUTriad(v []byte) uint32 {
+	return uint32(v[0]) | uint32(v[1])<<8 | uint32(v[2])<<16
+}
+
+func WriteLUTriad(v uint32) []byte {
+	return []byte{
+		byte(v),
+		byte(v >> 8),
+		byte(v >> 16),
+	}
+}
+
 func ReadInt(v []byte) int32 {
 	return int32(v[0])<<24 | int32(v[1])<<16 | int32(v[2])<<8 | int32(v[3])
 }
@@ -258,7 +302,185 @@ func ReadLDouble(v []byte) float64 {
 }
 
 func WriteLDouble(v float64) []byte {
-	return WriteULong(math.Float64bits(v))
+	return WriteLULong(math.Float64bits(v))
+}
+
+// Append
+
+func AppendByte(dst []byte, v byte) []byte {
+	return append(dst,
+		byte(v),
+	)
+}
+
+func AppendSByte(dst []byte, v int8) []byte {
+	return append(dst,
+		byte(v),
+	)
+}
+
+func AppendShort(dst []byte, v int16) []byte {
+	return append(dst,
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendLShort(dst []byte, v int16) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+	)
+}
+
+func AppendUShort(dst []byte, v uint16) []byte {
+	return append(dst,
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendLUShort(dst []byte, v uint16) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+	)
+}
+
+func AppendTriad(dst []byte, v int32) []byte {
+	return append(dst,
+		byte(v>>16),
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendUTriad(dst []byte, v uint32) []byte {
+	return append(dst,
+		byte(v>>16),
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendLTriad(dst []byte, v int32) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+		byte(v>>16),
+	)
+}
+
+func AppendLUTriad(dst []byte, v uint32) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+		byte(v>>16),
+	)
+}
+
+func AppendInt(dst []byte, v int32) []byte {
+	return append(dst,
+		byte(v>>24),
+		byte(v>>16),
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendUInt(dst []byte, v uint32) []byte {
+	return append(dst,
+		byte(v>>24),
+		byte(v>>16),
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendLInt(dst []byte, v int32) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+		byte(v>>16),
+		byte(v>>24),
+	)
+}
+
+func AppendLUInt(dst []byte, v uint32) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+		byte(v>>16),
+		byte(v>>24),
+	)
+}
+
+func AppendLong(dst []byte, v int64) []byte {
+	return append(dst,
+		byte(v>>56),
+		byte(v>>48),
+		byte(v>>40),
+		byte(v>>32),
+		byte(v>>24),
+		byte(v>>16),
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendULong(dst []byte, v uint64) []byte {
+	return append(dst,
+		byte(v>>56),
+		byte(v>>48),
+		byte(v>>40),
+		byte(v>>32),
+		byte(v>>24),
+		byte(v>>16),
+		byte(v>>8),
+		byte(v),
+	)
+}
+
+func AppendLLong(dst []byte, v int64) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+		byte(v>>16),
+		byte(v>>24),
+		byte(v>>32),
+		byte(v>>40),
+		byte(v>>48),
+		byte(v>>56),
+	)
+}
+
+func AppendLULong(dst []byte, v uint64) []byte {
+	return append(dst,
+		byte(v),
+		byte(v>>8),
+		byte(v>>16),
+		byte(v>>24),
+		byte(v>>32),
+		byte(v>>40),
+		byte(v>>48),
+		byte(v>>56),
+	)
+}
+
+func AppendFloat(dst []byte, v float32) []byte {
+	return AppendUInt(dst, math.Float32bits(v))
+}
+
+func AppendLFloat(dst []byte, v float32) []byte {
+	return AppendLUInt(dst, math.Float32bits(v))
+}
+
+func AppendDouble(dst []byte, v float64) []byte {
+	return AppendULong(dst, math.Float64bits(v))
+}
+
+func AppendLDouble(dst []byte, v float64) []byte {
+	return AppendLULong(dst, math.Float64bits(v))
 }
 
 func ReadEByte(v []byte) (byte, error) {
@@ -309,6 +531,38 @@ func ReadELUShort(v []byte) (uint16, error) {
 	return ReadLUShort(v), nil
 }
 
+func ReadETriad(v []byte) (int32, error) {
+	if len(v) < TriadSize {
+		return 0, ErrNotEnought
+	}
+
+	return ReadTriad(v), nil
+}
+
+func ReadEUTriad(v []byte) (uint32, error) {
+	if len(v) < TriadSize {
+		return 0, ErrNotEnought
+	}
+
+	return ReadUTriad(v), nil
+}
+
+func ReadELTriad(v []byte) (int32, error) {
+	if len(v) < TriadSize {
+		return 0, ErrNotEnought
+	}
+
+	return ReadLTriad(v), nil
+}
+
+func ReadELUTriad(v []byte) (uint32, error) {
+	if len(v) < TriadSize {
+		return 0, ErrNotEnought
+	}
+
+	return ReadLUTriad(v), nil
+}
+
 func ReadEInt(v []byte) (int32, error) {
 	if len(v) < IntSize {
 		return 0, ErrNotEnought
@@ -419,7 +673,18 @@ func Read(reader io.Reader, order Order, data interface{}) error {
 	}
 
 	if n < size {
-		return ErrNotEnought
+		typ := reflect.TypeOf(data)
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		return &DecodeError{
+			Off:   -1,
+			Type:  typ.String(),
+			Need:  size,
+			Avail: n,
+			Err:   ErrNotEnought,
+		}
 	}
 
 	switch value := data.(type) {
@@ -526,6 +791,8 @@ type Order interface {
 	SByte(v []byte) int8
 	Short(v []byte) int16
 	UShort(v []byte) uint16
+	Triad(v []byte) int32
+	UTriad(v []byte) uint32
 	Int(v []byte) int32
 	UInt(v []byte) uint32
 	Long(v []byte) int64
@@ -536,12 +803,26 @@ type Order interface {
 	PutSByte(v int8) []byte
 	PutShort(v int16) []byte
 	PutUShort(v uint16) []byte
+	PutTriad(v int32) []byte
+	PutUTriad(v uint32) []byte
 	PutInt(v int32) []byte
 	PutUInt(v uint32) []byte
 	PutLong(v int64) []byte
 	PutULong(v uint64) []byte
 	PutFloat(v float32) []byte
 	PutDouble(v float64) []byte
+	AppendByte(dst []byte, v byte) []byte
+	AppendSByte(dst []byte, v int8) []byte
+	AppendShort(dst []byte, v int16) []byte
+	AppendUShort(dst []byte, v uint16) []byte
+	AppendTriad(dst []byte, v int32) []byte
+	AppendUTriad(dst []byte, v uint32) []byte
+	AppendInt(dst []byte, v int32) []byte
+	AppendUInt(dst []byte, v uint32) []byte
+	AppendLong(dst []byte, v int64) []byte
+	AppendULong(dst []byte, v uint64) []byte
+	AppendFloat(dst []byte, v float32) []byte
+	AppendDouble(dst []byte, v float64) []byte
 }
 
 // BigEndian .
@@ -585,6 +866,22 @@ func (bigEndian) PutUShort(v uint16) []byte {
 	return WriteUShort(v)
 }
 
+func (bigEndian) Triad(v []byte) int32 {
+	return ReadTriad(v)
+}
+
+func (bigEndian) PutTriad(v int32) []byte {
+	return WriteTriad(v)
+}
+
+func (bigEndian) UTriad(v []byte) uint32 {
+	return ReadUTriad(v)
+}
+
+func (bigEndian) PutUTriad(v uint32) []byte {
+	return WriteUTriad(v)
+}
+
 func (bigEndian) Int(v []byte) int32 {
 	return ReadInt(v)
 }
@@ -633,6 +930,54 @@ func (bigEndian) PutDouble(v float64) []byte {
 	return WriteDouble(v)
 }
 
+func (bigEndian) AppendByte(dst []byte, v byte) []byte {
+	return AppendByte(dst, v)
+}
+
+func (bigEndian) AppendSByte(dst []byte, v int8) []byte {
+	return AppendSByte(dst, v)
+}
+
+func (bigEndian) AppendShort(dst []byte, v int16) []byte {
+	return AppendShort(dst, v)
+}
+
+func (bigEndian) AppendUShort(dst []byte, v uint16) []byte {
+	return AppendUShort(dst, v)
+}
+
+func (bigEndian) AppendTriad(dst []byte, v int32) []byte {
+	return AppendTriad(dst, v)
+}
+
+func (bigEndian) AppendUTriad(dst []byte, v uint32) []byte {
+	return AppendUTriad(dst, v)
+}
+
+func (bigEndian) AppendInt(dst []byte, v int32) []byte {
+	return AppendInt(dst, v)
+}
+
+func (bigEndian) AppendUInt(dst []byte, v uint32) []byte {
+	return AppendUInt(dst, v)
+}
+
+func (bigEndian) AppendLong(dst []byte, v int64) []byte {
+	return AppendLong(dst, v)
+}
+
+func (bigEndian) AppendULong(dst []byte, v uint64) []byte {
+	return AppendULong(dst, v)
+}
+
+func (bigEndian) AppendFloat(dst []byte, v float32) []byte {
+	return AppendFloat(dst, v)
+}
+
+func (bigEndian) AppendDouble(dst []byte, v float64) []byte {
+	return AppendDouble(dst, v)
+}
+
 type littleEndian struct {
 }
 
@@ -668,6 +1013,22 @@ func (littleEndian) PutUShort(v uint16) []byte {
 	return WriteLUShort(v)
 }
 
+func (littleEndian) Triad(v []byte) int32 {
+	return ReadLTriad(v)
+}
+
+func (littleEndian) PutTriad(v int32) []byte {
+	return WriteLTriad(v)
+}
+
+func (littleEndian) UTriad(v []byte) uint32 {
+	return ReadLUTriad(v)
+}
+
+func (littleEndian) PutUTriad(v uint32) []byte {
+	return WriteLUTriad(v)
+}
+
 func (littleEndian) Int(v []byte) int32 {
 	return ReadLInt(v)
 }
@@ -715,3 +1076,51 @@ func (littleEndian) Double(v []byte) float64 {
 func (littleEndian) PutDouble(v float64) []byte {
 	return WriteLDouble(v)
 }
+
+func (littleEndian) AppendByte(dst []byte, v byte) []byte {
+	return AppendByte(dst, v)
+}
+
+func (littleEndian) AppendSByte(dst []byte, v int8) []byte {
+	return AppendSByte(dst, v)
+}
+
+func (littleEndian) AppendShort(dst []byte, v int16) []byte {
+	return AppendLShort(dst, v)
+}
+
+func (littleEndian) AppendUShort(dst []byte, v uint16) []byte {
+	return AppendLUShort(dst, v)
+}
+
+func (littleEndian) AppendTriad(dst []byte, v int32) []byte {
+	return AppendLTriad(dst, v)
+}
+
+func (littleEndian) AppendUTriad(dst []byte, v uint32) []byte {
+	return AppendLUTriad(dst, v)
+}
+
+func (littleEndian) AppendInt(dst []byte, v int32) []byte {
+	return AppendLInt(dst, v)
+}
+
+func (littleEndian) AppendUInt(dst []byte, v uint32) []byte {
+	return AppendLUInt(dst, v)
+}
+
+func (littleEndian) AppendLong(dst []byte, v int64) []byte {
+	return AppendLLong(dst, v)
+}
+
+func (littleEndian) AppendULong(dst []byte, v uint64) []byte {
+	return AppendLULong(dst, v)
+}
+
+func (littleEndian) AppendFloat(dst []byte, v float32) []byte {
+	return AppendLFloat(dst, v)
+}
+
+func (littleEndian) AppendDouble(dst []byte, v float64) []byte {
+	return AppendLDouble(dst, v)
+}
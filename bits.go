@@ -0,0 +1,216 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidBits is returned when a count of bits is out of 1 - 64
+	ErrInvalidBits = errors.New("binary: invalid count of bits")
+
+	// ErrBitsOverflow is returned when a value doesn't fit in the bits
+	ErrBitsOverflow = errors.New("binary: value overflows the bits")
+)
+
+// BitOrder is an order of bits in a byte
+type BitOrder int
+
+const (
+	// MSBFirst reads and writes bits from the most significant bit of a byte
+	// Values are also stored from the most significant bit
+	MSBFirst BitOrder = iota
+
+	// LSBFirst reads and writes bits from the least significant bit of a byte
+	// Values are also stored from the least significant bit
+	LSBFirst
+)
+
+// NewBitStream returns new BitStream with the stream
+func NewBitStream(stream *Stream, order BitOrder) *BitStream {
+	return &BitStream{
+		Stream: stream,
+		Order:  order,
+	}
+}
+
+// BitStream is a stream reading and writing bits
+//
+// Bits are read from and written to the buffer of the Stream.
+// Byte values can also be read and written with the Stream, and they start at the next byte boundary.
+// Unused bits of a written partial byte are 0.
+type BitStream struct {
+	*Stream
+	Order BitOrder
+
+	rn   int // count of read bits of the byte before roff
+	roff int // offset after the partial byte for reading
+	wn   int // count of written bits of the last byte
+	wlen int // len of buf after the partial byte for writing
+}
+
+// readable returns count of bits left in the partial byte for reading
+func (bs *BitStream) readable() int {
+	if bs.rn <= 0 || bs.rn >= 8 || bs.roff != bs.off {
+		return 0
+	}
+
+	return 8 - bs.rn
+}
+
+// writable returns count of bits left in the partial byte for writing
+func (bs *BitStream) writable() int {
+	if bs.wn <= 0 || bs.wn >= 8 || bs.wlen != len(bs.buf) {
+		return 0
+	}
+
+	return 8 - bs.wn
+}
+
+// readBit reads a bit
+// The stream must have a bit
+func (bs *BitStream) readBit() uint64 {
+	if bs.readable() == 0 {
+		bs.off++
+		bs.rn = 0
+		bs.roff = bs.off
+	}
+
+	c := bs.buf[bs.off-1]
+
+	var bit byte
+	if bs.Order == LSBFirst {
+		bit = c >> uint(bs.rn) & 1
+	} else {
+		bit = c >> uint(7-bs.rn) & 1
+	}
+
+	bs.rn++
+
+	return uint64(bit)
+}
+
+// writeBit writes a bit
+func (bs *BitStream) writeBit(bit uint64) {
+	if bs.writable() == 0 {
+		bs.buf = append(bs.buf, 0)
+		bs.wn = 0
+		bs.wlen = len(bs.buf)
+	}
+
+	if bs.Order == LSBFirst {
+		bs.buf[len(bs.buf)-1] |= byte(bit) << uint(bs.wn)
+	} else {
+		bs.buf[len(bs.buf)-1] |= byte(bit) << uint(7-bs.wn)
+	}
+
+	bs.wn++
+}
+
+// Bits gets an unsigned value of n bits
+func (bs *BitStream) Bits(n int) (uint64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	if n < 1 || n > 64 {
+		return 0, ErrInvalidBits
+	}
+
+	if rest := n - bs.readable(); rest > bs.Len()*8 {
+		return 0, bs.fail("Bits", (rest+7)/8, ErrNotEnought)
+	}
+
+	var v uint64
+	for i := 0; i < n; i++ {
+		if bs.Order == LSBFirst {
+			v |= bs.readBit() << uint(i)
+		} else {
+			v = v<<1 | bs.readBit()
+		}
+	}
+
+	return v, nil
+}
+
+// PutBits puts an unsigned value of n bits
+func (bs *BitStream) PutBits(n int, v uint64) error {
+	if n < 1 || n > 64 {
+		return ErrInvalidBits
+	}
+
+	if n < 64 && v>>uint(n) != 0 {
+		return ErrBitsOverflow
+	}
+
+	for i := 0; i < n; i++ {
+		if bs.Order == LSBFirst {
+			bs.writeBit(v >> uint(i) & 1)
+		} else {
+			bs.writeBit(v >> uint(n-1-i) & 1)
+		}
+	}
+
+	return nil
+}
+
+// SBits gets a signed value of n bits (two's complement)
+func (bs *BitStream) SBits(n int) (int64, error) {
+	v, err := bs.Bits(n)
+	if err != nil {
+		return 0, err
+	}
+
+	shift := uint(64 - n)
+
+	return int64(v<<shift) >> shift, nil
+}
+
+// PutSBits puts a signed value of n bits (two's complement)
+func (bs *BitStream) PutSBits(n int, v int64) error {
+	if n < 1 || n > 64 {
+		return ErrInvalidBits
+	}
+
+	shift := uint(64 - n)
+	if v<<shift>>shift != v {
+		return ErrBitsOverflow
+	}
+
+	return bs.PutBits(n, uint64(v)<<shift>>shift)
+}
+
+// Bit gets a bit as bool
+func (bs *BitStream) Bit() (bool, error) {
+	v, err := bs.Bits(1)
+	if err != nil {
+		return false, err
+	}
+
+	return v != 0, nil
+}
+
+// PutBit puts a bool as a bit
+func (bs *BitStream) PutBit(value bool) error {
+	var v uint64
+	if value {
+		v = 1
+	}
+
+	return bs.PutBits(1, v)
+}
+
+// Align skips the rest bits of the partial bytes for reading and writing
+// The next bits are read and written from the next byte boundary
+func (bs *BitStream) Align() {
+	bs.rn = 0
+	bs.wn = 0
+}
@@ -0,0 +1,152 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBitStreamMSBFirst(t *testing.T) {
+	stream := NewBitStream(NewStream(), MSBFirst)
+
+	stream.PutBits(3, 0x5)
+	stream.PutBit(true)
+	stream.PutBits(12, 0xabc)
+	stream.PutSBits(4, -2)
+
+	exp := []byte{0xba, 0xbc, 0xe0}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	if v, _ := stream.Bits(3); v != 0x5 {
+		t.Fatalf("Expected %x for bits, but %x", 0x5, v)
+	}
+
+	if v, _ := stream.Bit(); !v {
+		t.Fatalf("Expected %t for bit, but %t", true, v)
+	}
+
+	if v, _ := stream.Bits(12); v != 0xabc {
+		t.Fatalf("Expected %x for bits, but %x", 0xabc, v)
+	}
+
+	if v, _ := stream.SBits(4); v != -2 {
+		t.Fatalf("Expected %d for bits, but %d", -2, v)
+	}
+}
+
+func TestBitStreamLSBFirst(t *testing.T) {
+	stream := NewBitStream(NewStream(), LSBFirst)
+
+	stream.PutBits(3, 0x5)
+	stream.PutBit(true)
+	stream.PutBits(12, 0xabc)
+	stream.PutSBits(4, -2)
+
+	exp := []byte{0xcd, 0xab, 0x0e}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	if v, _ := stream.Bits(3); v != 0x5 {
+		t.Fatalf("Expected %x for bits, but %x", 0x5, v)
+	}
+
+	if v, _ := stream.Bit(); !v {
+		t.Fatalf("Expected %t for bit, but %t", true, v)
+	}
+
+	if v, _ := stream.Bits(12); v != 0xabc {
+		t.Fatalf("Expected %x for bits, but %x", 0xabc, v)
+	}
+
+	if v, _ := stream.SBits(4); v != -2 {
+		t.Fatalf("Expected %d for bits, but %d", -2, v)
+	}
+}
+
+func TestBitStream64(t *testing.T) {
+	for _, order := range []BitOrder{MSBFirst, LSBFirst} {
+		stream := NewBitStream(NewStream(), order)
+
+		stream.PutBit(true)
+		stream.PutBits(64, 0x0123456789abcdef)
+		stream.PutSBits(64, -1)
+
+		stream.Bit()
+
+		if v, _ := stream.Bits(64); v != 0x0123456789abcdef {
+			t.Fatalf("Expected %x for bits, but %x", uint64(0x0123456789abcdef), v)
+		}
+
+		if v, _ := stream.SBits(64); v != -1 {
+			t.Fatalf("Expected %d for bits, but %d", -1, v)
+		}
+	}
+}
+
+func TestBitStreamBytes(t *testing.T) {
+	stream := NewBitStream(NewStream(), MSBFirst)
+
+	stream.PutBits(4, 0xf)
+	stream.PutShort(0x1234) // starts at the next byte
+	stream.PutBits(2, 0x3)
+	stream.Align()
+	stream.PutBits(2, 0x1)
+
+	exp := []byte{0xf0, 0x12, 0x34, 0xc0, 0x40}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	stream.Bits(4)
+
+	if v, _ := stream.Short(); v != 0x1234 {
+		t.Fatalf("Expected %x for short, but %x", 0x1234, v)
+	}
+
+	stream.Bits(2)
+	stream.Align()
+
+	if v, _ := stream.Bits(2); v != 0x1 {
+		t.Fatalf("Expected %x for bits, but %x", 0x1, v)
+	}
+}
+
+func TestBitStreamError(t *testing.T) {
+	stream := NewBitStream(NewStreamBytes([]byte{0xff}), MSBFirst)
+
+	if _, err := stream.Bits(0); !errors.Is(err, ErrInvalidBits) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidBits, err)
+	}
+
+	if err := stream.PutBits(4, 0x10); !errors.Is(err, ErrBitsOverflow) {
+		t.Fatalf("Expected %v, but %v", ErrBitsOverflow, err)
+	}
+
+	if err := stream.PutSBits(4, 8); !errors.Is(err, ErrBitsOverflow) {
+		t.Fatalf("Expected %v, but %v", ErrBitsOverflow, err)
+	}
+
+	stream.Bits(5)
+
+	_, err := stream.Bits(4)
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+
+	// the failed read doesn't consume bits
+	if stream.Off() != 1 {
+		t.Fatalf("Expected %d for offset, but %d", 1, stream.Off())
+	}
+}
@@ -0,0 +1,127 @@
+// Package cfb8 implements CFB-8 mode (8-bit cipher feedback), which crypto/cipher doesn't have
+//
+// It's used by encryption of Minecraft Java Edition.
+package cfb8
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"crypto/cipher"
+	"io"
+)
+
+// NewEncrypter returns a cipher.Stream encrypting with CFB-8 mode
+// The length of iv must be the block size. It panics if it's not, like crypto/cipher.
+func NewEncrypter(block cipher.Block, iv []byte) cipher.Stream {
+	return newCFB8(block, iv, false)
+}
+
+// NewDecrypter returns a cipher.Stream decrypting with CFB-8 mode
+// The length of iv must be the block size. It panics if it's not, like crypto/cipher.
+func NewDecrypter(block cipher.Block, iv []byte) cipher.Stream {
+	return newCFB8(block, iv, true)
+}
+
+func newCFB8(block cipher.Block, iv []byte, decrypt bool) *cfb8 {
+	size := block.BlockSize()
+	if len(iv) != size {
+		panic("cfb8: IV length must equal block size")
+	}
+
+	c := &cfb8{
+		block:   block,
+		size:    size,
+		reg:     make([]byte, size*2),
+		out:     make([]byte, size),
+		decrypt: decrypt,
+	}
+
+	copy(c.reg, iv)
+
+	return c
+}
+
+// cfb8 is a cipher.Stream of CFB-8 mode
+type cfb8 struct {
+	block   cipher.Block
+	size    int
+	reg     []byte // shift register, reg[pos:pos+size] is the current one
+	pos     int
+	out     []byte
+	decrypt bool
+}
+
+func (c *cfb8) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("cfb8: output smaller than input")
+	}
+
+	for i, b := range src {
+		c.block.Encrypt(c.out, c.reg[c.pos:c.pos+c.size])
+
+		// the ciphertext is fed back, which is src when decrypting
+		// b is copied before writing dst because dst and src may be the same
+		dst[i] = b ^ c.out[0]
+		if !c.decrypt {
+			b = dst[i]
+		}
+
+		c.reg[c.pos+c.size] = b
+		c.pos++
+
+		// moves the register to the head instead of shifting for each byte
+		if c.pos == c.size {
+			copy(c.reg, c.reg[c.size:])
+			c.pos = 0
+		}
+	}
+}
+
+// NewReader returns an io.Reader decrypting data from r
+func NewReader(r io.Reader, block cipher.Block, iv []byte) io.Reader {
+	return &cipher.StreamReader{
+		S: NewDecrypter(block, iv),
+		R: r,
+	}
+}
+
+// NewWriter returns an io.Writer encrypting data to w
+func NewWriter(w io.Writer, block cipher.Block, iv []byte) *Writer {
+	return &Writer{
+		s: NewEncrypter(block, iv),
+		w: w,
+	}
+}
+
+// Writer is an io.Writer encrypting data
+// It reuses the buffer for encrypted data, unlike crypto/cipher.StreamWriter.
+type Writer struct {
+	s   cipher.Stream
+	w   io.Writer
+	buf []byte
+}
+
+// Write encrypts p and writes it
+// The stream can't be recovered if it returns an error because the key stream has been advanced.
+func (w *Writer) Write(p []byte) (int, error) {
+	if cap(w.buf) < len(p) {
+		w.buf = make([]byte, len(p))
+	}
+
+	b := w.buf[:len(p)]
+	w.s.XORKeyStream(b, p)
+
+	n, err := w.w.Write(b)
+	if err == nil && n != len(p) {
+		err = io.ErrShortWrite
+	}
+
+	return n, err
+}
@@ -0,0 +1,150 @@
+package cfb8
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+// vectors are CFB8-AES of NIST SP 800-38A F.3.7, F.3.9 and F.3.11
+var vectors = []struct {
+	key        string
+	iv         string
+	plaintext  string
+	ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"000102030405060708090a0b0c0d0e0f",
+		"6bc1bee22e409f96e93d7e117393172aae2d",
+		"3b79424c9c0dd436bace9e0ed4586a4f32b9",
+	},
+	{
+		"8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b",
+		"000102030405060708090a0b0c0d0e0f",
+		"6bc1bee22e409f96e93d7e117393172aae2d",
+		"cda2521ef0a905ca44cd057cbf0d47a0678a",
+	},
+	{
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"000102030405060708090a0b0c0d0e0f",
+		"6bc1bee22e409f96e93d7e117393172aae2d",
+		"dc1f1a8520a64db55fcc8ac554844e889700",
+	},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("Failed to decode hex Error: %s", err)
+	}
+
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		key := decodeHex(t, v.key)
+		iv := decodeHex(t, v.iv)
+		plaintext := decodeHex(t, v.plaintext)
+		ciphertext := decodeHex(t, v.ciphertext)
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			t.Fatalf("Failed to create a cipher Error: %s", err)
+		}
+
+		// byte by byte
+		enc := NewEncrypter(block, iv)
+		got := make([]byte, len(plaintext))
+		for i := range plaintext {
+			enc.XORKeyStream(got[i:i+1], plaintext[i:i+1])
+		}
+
+		if !bytes.Equal(got, ciphertext) {
+			t.Errorf("Expected %x for encrypting with %d bits key, but %x", ciphertext, len(key)*8, got)
+		}
+
+		// in place
+		got = append([]byte(nil), ciphertext...)
+		NewDecrypter(block, iv).XORKeyStream(got, got)
+
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("Expected %x for decrypting with %d bits key, but %x", plaintext, len(key)*8, got)
+		}
+	}
+}
+
+func TestReaderWriter(t *testing.T) {
+	key := decodeHex(t, vectors[0].key)
+	iv := decodeHex(t, vectors[0].iv)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("Failed to create a cipher Error: %s", err)
+	}
+
+	data := bytes.Repeat([]byte("binary cfb8 "), 100)
+
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf, block, iv)
+
+	for i := 0; i < len(data); i += 7 {
+		end := min(i+7, len(data))
+		if _, err := w.Write(data[i:end]); err != nil {
+			t.Fatalf("Failed to write Error: %s", err)
+		}
+	}
+
+	encrypted := make([]byte, len(data))
+	NewEncrypter(block, iv).XORKeyStream(encrypted, data)
+
+	if !bytes.Equal(buf.Bytes(), encrypted) {
+		t.Errorf("Expected the same bytes as XORKeyStream, but not")
+	}
+
+	got, err := io.ReadAll(NewReader(iotest.OneByteReader(buf), block, iv))
+	if err != nil {
+		t.Fatalf("Failed to read Error: %s", err)
+	}
+
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected %q, but %q", data, got)
+	}
+}
+
+func TestInvalidIV(t *testing.T) {
+	block, _ := aes.NewCipher(make([]byte, 16))
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for invalid IV, but not")
+		}
+	}()
+
+	NewEncrypter(block, make([]byte, 8))
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	block, _ := aes.NewCipher(make([]byte, 16))
+	stream := NewEncrypter(block, make([]byte, 16))
+
+	buf := make([]byte, 1024)
+	b.SetBytes(int64(len(buf)))
+
+	for i := 0; i < b.N; i++ {
+		stream.XORKeyStream(buf, buf)
+	}
+}
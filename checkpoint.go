@@ -0,0 +1,78 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+// Checkpoint is a state of Stream returned by Mark
+type Checkpoint struct {
+	off     int
+	len     int
+	correct bool
+	err     error
+	errOff  int
+	lengths int // count of marks of BeginLength
+	ended   int // count of marks ended by EndLength
+}
+
+// Mark returns a checkpoint of the current state
+// Checkpoints can be nested, and rewinding to an outer one also discards inner ones
+func (bs *Stream) Mark() Checkpoint {
+	return Checkpoint{
+		off:     bs.off,
+		len:     len(bs.buf),
+		correct: bs.correct,
+		err:     bs.err,
+		errOff:  bs.errOff,
+		lengths: len(bs.lengths),
+		ended:   len(bs.ended),
+	}
+}
+
+// Rewind restores the state of the checkpoint
+// It restores offset and the error, and truncates bytes put after the checkpoint.
+// BeginLength called after the checkpoint is discarded, and EndLength called after it is undone
+// including bytes moved by EndLength of PrefixVarInt.
+func (bs *Stream) Rewind(cp Checkpoint) {
+	if cp.len <= len(bs.buf) && cp.ended <= len(bs.ended) {
+		// undoes in reverse order, marks begun after the checkpoint only changed truncated bytes
+		for i := len(bs.ended) - 1; i >= cp.ended; i-- {
+			if e := bs.ended[i]; e.off < cp.len {
+				bs.unendLength(e)
+			}
+		}
+
+		bs.buf = bs.buf[:cp.len]
+		bs.ended = bs.ended[:cp.ended]
+		if cp.lengths <= len(bs.lengths) {
+			bs.lengths = bs.lengths[:cp.lengths]
+		}
+	}
+
+	bs.off = cp.off
+	if bs.off > len(bs.buf) {
+		bs.off = len(bs.buf)
+	}
+
+	bs.correct = cp.correct
+	bs.err = cp.err
+	bs.errOff = cp.errOff
+}
+
+// Transaction calls fn, and rewinds the stream if fn returns an error
+// It returns the error of fn
+func (bs *Stream) Transaction(fn func() error) error {
+	cp := bs.Mark()
+
+	err := fn()
+	if err != nil {
+		bs.Rewind(cp)
+	}
+
+	return err
+}
@@ -0,0 +1,179 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStreamRewind(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x01, 0x02, 0x03})
+
+	outer := stream.Mark()
+
+	stream.Byte()
+	stream.PutByte(0x04)
+
+	inner := stream.Mark()
+
+	stream.Byte()
+	stream.PutByte(0x05)
+	stream.Int() // fails
+
+	stream.Rewind(inner)
+
+	if stream.Err() != nil {
+		t.Fatalf("Expected no error, but %v", stream.Err())
+	}
+
+	if stream.Off() != 1 {
+		t.Fatalf("Expected %d for offset, but %d", 1, stream.Off())
+	}
+
+	exp := []byte{0x01, 0x02, 0x03, 0x04}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	stream.Rewind(outer)
+
+	if stream.Off() != 0 {
+		t.Fatalf("Expected %d for offset, but %d", 0, stream.Off())
+	}
+
+	exp = []byte{0x01, 0x02, 0x03}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+}
+
+func TestStreamTransaction(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x00, 0x01, 0x02})
+
+	errTest := errors.New("test")
+
+	// try a layout with an int, and fall back to a short
+	var ival int32
+	err := stream.Transaction(func() error {
+		var err error
+		ival, err = stream.Int()
+
+		return err
+	})
+
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+
+	if stream.Err() != nil || stream.Off() != 0 {
+		t.Fatalf("Expected the stream rewound, but offset %d Error: %v", stream.Off(), stream.Err())
+	}
+
+	err = stream.Transaction(func() error {
+		stream.PutByte(0xff)
+
+		return errTest
+	})
+
+	if err != errTest {
+		t.Fatalf("Expected %v, but %v", errTest, err)
+	}
+
+	if stream.Len() != 3 {
+		t.Fatalf("Expected %d for len, but %d", 3, stream.Len())
+	}
+
+	var sval uint16
+	err = stream.Transaction(func() error {
+		var err error
+		sval, err = stream.Short()
+
+		return err
+	})
+
+	if err != nil || sval != 0x0001 || ival != 0 {
+		t.Fatalf("Expected %x for short, but %x Error: %v", 0x0001, sval, err)
+	}
+
+	if stream.Off() != 2 {
+		t.Fatalf("Expected %d for offset, but %d", 2, stream.Off())
+	}
+}
+
+func TestStreamRewindLength(t *testing.T) {
+	stream := NewStream()
+	stream.BeginLength(PrefixByte)
+	stream.BeginLength(PrefixByte)
+	stream.PutByte(0x01)
+
+	// the rolled back transaction ends a length, and begins another one
+	err := stream.Transaction(func() error {
+		stream.EndLength()
+		stream.EndLength()
+		stream.BeginLength(PrefixShort)
+		stream.PutInt(0)
+
+		return errors.New("test")
+	})
+
+	if err == nil {
+		t.Fatalf("Expected an error, but nil")
+	}
+
+	stream.PutByte(0x02)
+	if err := stream.EndLength(); err != nil {
+		t.Fatalf("Failed to end length Error: %s", err)
+	}
+
+	if err := stream.EndLength(); err != nil {
+		t.Fatalf("Failed to end length Error: %s", err)
+	}
+
+	exp := []byte{0x03, 0x02, 0x01, 0x02}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	if err := stream.EndLength(); err != ErrNoLength {
+		t.Fatalf("Expected %v, but %v", ErrNoLength, err)
+	}
+}
+
+func TestStreamRewindLengthVarInt(t *testing.T) {
+	stream := NewStream()
+	stream.BeginLength(PrefixVarInt)
+	stream.PutByte(0x01)
+
+	// EndLength of the length begun before the checkpoint moves the bytes
+	cp := stream.Mark()
+	stream.Pad(200)
+	if err := stream.EndLength(); err != nil {
+		t.Fatalf("Failed to end length Error: %s", err)
+	}
+
+	stream.Rewind(cp)
+
+	exp := []byte{0x00, 0x01}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	stream.PutByte(0x02)
+	if err := stream.EndLength(); err != nil {
+		t.Fatalf("Failed to end length Error: %s", err)
+	}
+
+	exp = []byte{0x02, 0x01, 0x02}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+}
@@ -0,0 +1,956 @@
+package main
+
+/*
+	Binarygen
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/beito123/binary"
+)
+
+const header = "// Code generated by binarygen. DO NOT EDIT.\n\n"
+
+// basicTypes is types supported by the generator
+var basicTypes = map[string]bool{
+	"bool":    true,
+	"byte":    true,
+	"uint8":   true,
+	"int8":    true,
+	"int16":   true,
+	"uint16":  true,
+	"int32":   true,
+	"rune":    true,
+	"uint32":  true,
+	"int64":   true,
+	"uint64":  true,
+	"float32": true,
+	"float64": true,
+	"string":  true,
+}
+
+// field is an encoded field of a struct
+type field struct {
+	name string
+	typ  ast.Expr
+	tag  binary.Tag
+}
+
+type generator struct {
+	little  bool // order of the methods being generated
+	base    bool // order of MarshalStream and UnmarshalStream
+	pkgName string
+	structs map[string]*ast.StructType
+	named   map[string]string // named types of basic types
+
+	buf     bytes.Buffer
+	usesErr bool
+	seq     int
+	current string // name of the struct being generated
+
+	pending []string        // structs used in the other order
+	other   map[string]bool // structs having methods of the other order
+}
+
+func newGenerator(p *pkg, little bool) *generator {
+	gen := &generator{
+		little:  little,
+		base:    little,
+		pkgName: p.name,
+		structs: make(map[string]*ast.StructType),
+		named:   make(map[string]string),
+		other:   make(map[string]bool),
+	}
+
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				switch t := ts.Type.(type) {
+				case *ast.StructType:
+					gen.structs[ts.Name.Name] = t
+				case *ast.Ident:
+					gen.named[ts.Name.Name] = t.Name
+				}
+			}
+		}
+	}
+
+	return gen
+}
+
+func (gen *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&gen.buf, format, args...)
+}
+
+// check writes a call returning an error
+func (gen *generator) check(format string, args ...interface{}) {
+	gen.printf("if err := "+format+"; err != nil {\nreturn err\n}\n", args...)
+}
+
+// basic returns the basic type of the type name
+func (gen *generator) basic(name string) (string, bool) {
+	for i := 0; i < 10; i++ { // follows named types
+		if basicTypes[name] {
+			return name, true
+		}
+
+		next, ok := gen.named[name]
+		if !ok {
+			break
+		}
+
+		name = next
+	}
+
+	return "", false
+}
+
+// fields returns encoded fields of the struct
+func (gen *generator) fields(name string) ([]field, error) {
+	st, ok := gen.structs[name]
+	if !ok {
+		return nil, fmt.Errorf("struct type %s not found", name)
+	}
+
+	var fields []field
+	for _, f := range st.Fields.List {
+		var tag binary.Tag
+		var err error
+		if f.Tag != nil {
+			raw, _ := strconv.Unquote(f.Tag.Value)
+			tag, err = binary.ParseTag(reflect.StructTag(raw).Get(binary.TagName))
+		} else {
+			tag, err = binary.ParseTag("")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+
+		if tag.Ignore {
+			continue
+		}
+
+		if at, ok := f.Type.(*ast.ArrayType); tag.Len != "" && (!ok || at.Len != nil) {
+			return nil, fmt.Errorf("%s: len can be used only on slices", name)
+		}
+
+		names := f.Names
+		if len(names) == 0 { // embedded
+			ident, ok := f.Type.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("%s: unsupported embedded field %s", name, types.ExprString(f.Type))
+			}
+
+			names = []*ast.Ident{ident}
+		}
+
+		for _, n := range names {
+			if !ast.IsExported(n.Name) {
+				continue
+			}
+
+			fields = append(fields, field{
+				name: n.Name,
+				typ:  f.Type,
+				tag:  tag,
+			})
+		}
+	}
+
+	return fields, nil
+}
+
+// method returns the name of Stream accessor and its value type
+func (gen *generator) method(basic string, tag binary.Tag) (string, string, error) {
+	little := gen.little
+	if tag.Order != nil {
+		little = tag.Order == binary.LittleEndian
+	}
+
+	l := ""
+	if little {
+		l = "L"
+	}
+
+	if tag.Triad && basic != "int32" && basic != "rune" && basic != "uint32" {
+		return "", "", fmt.Errorf("triad option can't be used on %s", basic)
+	}
+
+	if tag.VarInt && basic != "int32" && basic != "rune" && basic != "uint32" && basic != "int64" && basic != "uint64" {
+		return "", "", fmt.Errorf("varint option can't be used on %s", basic)
+	}
+
+	switch basic {
+	case "bool":
+		return "Bool", "bool", nil
+	case "byte", "uint8":
+		return "Byte", "byte", nil
+	case "int8":
+		return "SByte", "int8", nil
+	case "int16":
+		return l + "SShort", "int16", nil
+	case "uint16":
+		return l + "Short", "uint16", nil
+	case "int32", "rune":
+		if tag.VarInt {
+			return "VarInt", "int32", nil
+		} else if tag.Triad {
+			return l + "Triad", "int32", nil
+		}
+
+		return l + "Int", "int32", nil
+	case "uint32":
+		if tag.VarInt {
+			return "UVarInt", "uint32", nil
+		} else if tag.Triad {
+			return l + "UTriad", "uint32", nil
+		}
+
+		return l + "UInt", "uint32", nil
+	case "int64":
+		if tag.VarInt {
+			return "VarLong", "int64", nil
+		}
+
+		return l + "Long", "int64", nil
+	case "uint64":
+		if tag.VarInt {
+			return "UVarLong", "uint64", nil
+		}
+
+		return l + "ULong", "uint64", nil
+	case "float32":
+		return l + "Float", "float32", nil
+	case "float64":
+		return l + "Double", "float64", nil
+	}
+
+	return "", "", fmt.Errorf("unsupported type %s", basic)
+}
+
+// sameType returns whether the type name is the same type as the basic type
+func sameType(name, basic string) bool {
+	if name == basic {
+		return true
+	}
+
+	switch name {
+	case "byte", "uint8":
+		return basic == "byte" || basic == "uint8"
+	case "rune", "int32":
+		return basic == "rune" || basic == "int32"
+	}
+
+	return false
+}
+
+// prefixSize returns the minimum size of the prefix
+func prefixSize(prefix binary.Prefix) int {
+	switch prefix {
+	case binary.PrefixShort, binary.PrefixLShort:
+		return binary.ShortSize
+	case binary.PrefixInt, binary.PrefixLInt:
+		return binary.IntSize
+	}
+
+	return binary.ByteSize
+}
+
+// minSize returns the minimum count of bytes of a value of the type encoded with the tag
+// It's the same as the size used by reflection to check lengths of slices.
+func (gen *generator) minSize(typ ast.Expr, tag binary.Tag) int {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if _, ok := gen.structs[t.Name]; ok {
+			fields, err := gen.fields(t.Name)
+			if err != nil {
+				return 0
+			}
+
+			size := 0
+			for _, f := range fields {
+				size += f.tag.Skip + gen.minSize(f.typ, f.tag)
+			}
+
+			return size
+		}
+
+		basic, _ := gen.basic(t.Name)
+		switch basic {
+		case "bool", "byte", "uint8", "int8":
+			return binary.ByteSize
+		case "int16", "uint16":
+			return binary.ShortSize
+		case "int32", "rune", "uint32":
+			if tag.VarInt {
+				return binary.ByteSize
+			} else if tag.Triad {
+				return binary.TriadSize
+			}
+
+			return binary.IntSize
+		case "int64", "uint64":
+			if tag.VarInt {
+				return binary.ByteSize
+			}
+
+			return binary.LongSize
+		case "float32":
+			return binary.FloatSize
+		case "float64":
+			return binary.DoubleSize
+		case "string":
+			if tag.CString {
+				return binary.ByteSize
+			} else if tag.Fixed > 0 {
+				return tag.Fixed
+			}
+
+			return prefixSize(tag.Prefix)
+		}
+	case *ast.ArrayType:
+		if t.Len == nil { // slice
+			if tag.Len != "" {
+				return 0
+			}
+
+			return prefixSize(tag.Prefix)
+		}
+
+		n, err := strconv.Atoi(types.ExprString(t.Len))
+		if err != nil { // a constant, the size is unknown
+			return 0
+		}
+
+		tag.Len = ""
+
+		return n * gen.minSize(t.Elt, tag)
+	}
+
+	return 0
+}
+
+// typeString returns the type name formatted like reflect, which is used in DecodeError
+func (gen *generator) typeString(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		switch {
+		case t.Name == "byte":
+			return "uint8"
+		case t.Name == "rune":
+			return "int32"
+		case basicTypes[t.Name]:
+			return t.Name
+		}
+
+		return gen.pkgName + "." + t.Name
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[" + types.ExprString(t.Len) + "]" + gen.typeString(t.Elt)
+		}
+
+		return "[]" + gen.typeString(t.Elt)
+	}
+
+	return types.ExprString(typ)
+}
+
+// isByte returns whether the type is byte
+func isByte(typ ast.Expr) bool {
+	ident, ok := typ.(*ast.Ident)
+
+	return ok && (ident.Name == "byte" || ident.Name == "uint8")
+}
+
+// Generate returns source code of MarshalStream and UnmarshalStream methods of types
+func (gen *generator) Generate(names []string) ([]byte, error) {
+	gen.buf.Reset()
+
+	gen.printf(header)
+	gen.printf("package %s\n\n", gen.pkgName)
+	gen.printf("import (\n\"github.com/beito123/binary\"\n)\n")
+
+	gen.pending = nil
+	gen.other = make(map[string]bool)
+
+	for _, name := range names {
+		err := gen.methods(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// struct fields with big or little options use methods of the other order
+	gen.little = !gen.base
+	defer func() {
+		gen.little = gen.base
+	}()
+
+	for len(gen.pending) > 0 {
+		name := gen.pending[0]
+		gen.pending = gen.pending[1:]
+
+		err := gen.methods(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return format.Source(gen.buf.Bytes())
+}
+
+// orderSuffix returns the suffix of methods in the other order
+func (gen *generator) orderSuffix() string {
+	if gen.base {
+		return "Big"
+	}
+
+	return "Little"
+}
+
+// otherName returns the name of the method in the other order
+// e.g. marshalStreamBig for MarshalStream
+func (gen *generator) otherName(method string) string {
+	return strings.ToLower(method[:1]) + method[1:] + gen.orderSuffix()
+}
+
+// methodName returns the name of the method in the current order
+func (gen *generator) methodName(method string) string {
+	if gen.little == gen.base {
+		return method
+	}
+
+	return gen.otherName(method)
+}
+
+// methods writes the marshal and unmarshal methods of the struct in the current order
+func (gen *generator) methods(name string) error {
+	fields, err := gen.fields(name)
+	if err != nil {
+		return err
+	}
+
+	gen.current = name
+
+	method := gen.methodName("MarshalStream")
+	if method == "MarshalStream" {
+		gen.printf("\n// MarshalStream encodes %s to the stream\n", name)
+	} else {
+		gen.printf("\n// %s encodes %s to the stream in %s endian\n", method, name, strings.ToLower(gen.orderSuffix()))
+	}
+
+	gen.printf("func (v *%s) %s(bs *binary.Stream) error {\n", name, method)
+
+	for _, f := range fields {
+		err = gen.encodeField(f)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", name, f.name, err)
+		}
+
+		gen.printf("\n")
+	}
+
+	gen.printf("return nil\n}\n")
+
+	return gen.unmarshal(name, fields)
+}
+
+// nested returns the method name to call for the struct with the options
+func (gen *generator) nested(name, method string, tag binary.Tag) string {
+	little := gen.little
+	if tag.Order != nil {
+		little = tag.Order == binary.LittleEndian
+	}
+
+	if little == gen.base {
+		return method
+	}
+
+	if !gen.other[name] {
+		gen.other[name] = true
+		gen.pending = append(gen.pending, name)
+	}
+
+	return gen.otherName(method)
+}
+
+func (gen *generator) unmarshal(name string, fields []field) error {
+	// generates the body first to know whether err is used
+	start := gen.buf.Len()
+	gen.usesErr = false
+
+	for _, f := range fields {
+		err := gen.decodeField(f)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", name, f.name, err)
+		}
+
+		gen.printf("\n")
+	}
+
+	code := append([]byte(nil), gen.buf.Bytes()[start:]...)
+	gen.buf.Truncate(start)
+
+	method := gen.methodName("UnmarshalStream")
+	if method == "UnmarshalStream" {
+		gen.printf("\n// UnmarshalStream decodes %s from the stream\n", name)
+	} else {
+		gen.printf("\n// %s decodes %s from the stream in %s endian\n", method, name, strings.ToLower(gen.orderSuffix()))
+	}
+
+	gen.printf("func (v *%s) %s(bs *binary.Stream) error {\n", name, method)
+	if gen.usesErr {
+		gen.printf("var err error\n\n")
+	}
+
+	gen.buf.Write(code)
+	gen.printf("return nil\n}\n")
+
+	return nil
+}
+
+func (gen *generator) encodeField(f field) error {
+	if f.tag.Skip > 0 {
+		gen.check("bs.Pad(%d)", f.tag.Skip)
+	}
+
+	return gen.encode("v."+f.name, f.typ, f.tag, 0)
+}
+
+func (gen *generator) encode(expr string, typ ast.Expr, tag binary.Tag, depth int) error {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if _, ok := gen.structs[t.Name]; ok {
+			gen.check("%s.%s(bs)", expr, gen.nested(t.Name, "MarshalStream", tag))
+
+			return nil
+		}
+
+		basic, ok := gen.basic(t.Name)
+		if !ok {
+			return fmt.Errorf("unsupported type %s", t.Name)
+		}
+
+		if basic == "string" {
+			value := expr
+			if t.Name != "string" {
+				value = "string(" + expr + ")"
+			}
+
+			switch {
+			case tag.CString:
+				gen.check("bs.PutCString(%s)", value)
+			case tag.Fixed > 0:
+				gen.check("bs.PutFixedString(%d, %s)", tag.Fixed, value)
+			default:
+				gen.check("bs.PutString(binary.Prefix%s, %s)", tag.Prefix, value)
+			}
+
+			return nil
+		}
+
+		method, mtype, err := gen.method(basic, tag)
+		if err != nil {
+			return err
+		}
+
+		value := expr
+		if !sameType(t.Name, mtype) {
+			value = mtype + "(" + expr + ")"
+		}
+
+		gen.check("bs.Put%s(%s)", method, value)
+	case *ast.ArrayType:
+		if t.Len == nil { // slice
+			if tag.Len != "" {
+				// same as Marshal
+				gen.printf("if len(%s) != int(v.%s) {\nreturn &binary.FieldError{Struct: %q, Field: %q, Err: binary.ErrLenMismatch}\n}\n",
+					expr, tag.Len, gen.current, strings.TrimPrefix(expr, "v."))
+			} else {
+				gen.check("bs.PutLength(binary.Prefix%s, len(%s))", tag.Prefix, expr)
+			}
+		}
+
+		if isByte(t.Elt) {
+			if t.Len != nil {
+				expr += "[:]"
+			}
+
+			gen.check("bs.Put(%s)", expr)
+
+			return nil
+		}
+
+		tag.Len = ""
+		idx := "i" + strconv.Itoa(depth)
+
+		gen.printf("for %s := range %s {\n", idx, expr)
+		err := gen.encode(expr+"["+idx+"]", t.Elt, tag, depth+1)
+		if err != nil {
+			return err
+		}
+
+		gen.printf("}\n")
+	default:
+		return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+	}
+
+	return nil
+}
+
+func (gen *generator) decodeField(f field) error {
+	if f.tag.Skip > 0 {
+		gen.printf("if bs.Len() < %d {\nreturn bs.Fail(\"skip\", %d, binary.ErrNotEnought)\n}\n", f.tag.Skip, f.tag.Skip)
+		gen.printf("bs.Skip(%d)\n", f.tag.Skip)
+	}
+
+	return gen.decode("v."+f.name, f.typ, f.tag, 0)
+}
+
+// assign writes an assignment of the result of call
+func (gen *generator) assign(expr, call string, same bool, conv string) {
+	if same {
+		gen.usesErr = true
+		gen.printf("if %s, err = %s; err != nil {\nreturn err\n}\n", expr, call)
+
+		return
+	}
+
+	gen.printf("{\nx, err := %s\nif err != nil {\nreturn err\n}\n\n%s = %s(x)\n}\n", call, expr, conv)
+}
+
+func (gen *generator) decode(expr string, typ ast.Expr, tag binary.Tag, depth int) error {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if _, ok := gen.structs[t.Name]; ok {
+			gen.check("%s.%s(bs)", expr, gen.nested(t.Name, "UnmarshalStream", tag))
+
+			return nil
+		}
+
+		basic, ok := gen.basic(t.Name)
+		if !ok {
+			return fmt.Errorf("unsupported type %s", t.Name)
+		}
+
+		if basic == "string" {
+			var call string
+			switch {
+			case tag.CString:
+				call = "bs.CString()"
+			case tag.Fixed > 0:
+				call = fmt.Sprintf("bs.FixedString(%d)", tag.Fixed)
+			default:
+				call = fmt.Sprintf("bs.String(binary.Prefix%s)", tag.Prefix)
+			}
+
+			gen.assign(expr, call, t.Name == "string", t.Name)
+
+			return nil
+		}
+
+		method, mtype, err := gen.method(basic, tag)
+		if err != nil {
+			return err
+		}
+
+		gen.assign(expr, "bs."+method+"()", sameType(t.Name, mtype), t.Name)
+	case *ast.ArrayType:
+		if t.Len != nil { // array
+			if isByte(t.Elt) {
+				gen.printf("if len(%s) > bs.Len() {\nreturn bs.Fail(%q, len(%s), binary.ErrNotEnought)\n}\n", expr, gen.typeString(t), expr)
+				gen.printf("copy(%s[:], bs.Get(len(%s)))\n", expr, expr)
+
+				return nil
+			}
+
+			return gen.decodeElements(expr, t.Elt, tag, depth)
+		}
+
+		n := "n" + strconv.Itoa(depth)
+
+		gen.printf("{\n")
+		if tag.Len != "" {
+			gen.printf("%s := int(v.%s)\n", n, tag.Len)
+			gen.printf("if %s < 0 {\nreturn bs.Fail(%q, 0, binary.ErrNegativeLen)\n}\n\n", n, gen.typeString(t))
+			gen.printf("if bs.MaxLen() > 0 && %s > bs.MaxLen() {\nreturn bs.Fail(%q, %s, binary.ErrTooLong)\n}\n\n", n, gen.typeString(t), n)
+		} else {
+			gen.printf("%s, err := bs.Length(binary.Prefix%s)\nif err != nil {\nreturn err\n}\n\n", n, tag.Prefix)
+		}
+
+		elemTag := tag
+		elemTag.Len = ""
+
+		switch size := gen.minSize(t.Elt, elemTag); size {
+		case 0: // elements may have no bytes
+		case 1:
+			gen.printf("if %s > bs.Len() {\nreturn bs.Fail(%q, %s, binary.ErrNotEnought)\n}\n\n", n, gen.typeString(t), n)
+		default:
+			gen.printf("if %s > bs.Len()/%d {\nreturn bs.Fail(%q, %s*%d, binary.ErrNotEnought)\n}\n\n", n, size, gen.typeString(t), n, size)
+		}
+
+		if isByte(t.Elt) {
+			gen.printf("%s = append(%s[:0], bs.Get(%s)...)\n}\n", expr, expr, n)
+
+			return nil
+		}
+
+		gen.printf("if cap(%s) < %s {\n%s = make(%s, %s)\n} else {\n%s = %s[:%s]\n}\n\n",
+			expr, n, expr, types.ExprString(t), n, expr, expr, n)
+
+		err := gen.decodeElements(expr, t.Elt, tag, depth)
+		if err != nil {
+			return err
+		}
+
+		gen.printf("}\n")
+	default:
+		return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+	}
+
+	return nil
+}
+
+func (gen *generator) decodeElements(expr string, elem ast.Expr, tag binary.Tag, depth int) error {
+	tag.Len = ""
+	idx := "i" + strconv.Itoa(depth)
+
+	gen.printf("for %s := range %s {\n", idx, expr)
+	err := gen.decode(expr+"["+idx+"]", elem, tag, depth+1)
+	if err != nil {
+		return err
+	}
+
+	gen.printf("}\n")
+
+	return nil
+}
+
+// GenerateTests returns source code of round-trip tests of types
+func (gen *generator) GenerateTests(names []string) ([]byte, error) {
+	gen.buf.Reset()
+	gen.seq = 0
+
+	order := "binary.BigEndian"
+	if gen.little {
+		order = "binary.LittleEndian"
+	}
+
+	gen.printf(header)
+	gen.printf("package %s\n\n", gen.pkgName)
+	gen.printf("import (\n\"bytes\"\n\"io\"\n\"reflect\"\n\"testing\"\n\n\"github.com/beito123/binary\"\n)\n")
+
+	for _, name := range names {
+		// decoding strings allocates, and the count is unknown if slices have strings
+		strs, err := gen.countStrings(&ast.Ident{Name: name})
+		if err != nil {
+			return nil, err
+		}
+
+		gen.printf(`
+func Test%[1]sBinary(t *testing.T) {
+	var v, ret %[1]s
+	testFill%[1]s(&v)
+
+	bs := binary.NewStream()
+	if err := v.MarshalStream(bs); err != nil {
+		t.Fatalf("Failed to marshal Error: %%s", err)
+	}
+
+	exp, err := binary.Marshal(%[2]s, &v)
+	if err != nil {
+		t.Fatalf("Failed to marshal with reflection Error: %%s", err)
+	}
+
+	if !bytes.Equal(bs.Bytes(), exp) {
+		t.Fatalf("Expected %%d for bytes, but %%d", exp, bs.Bytes())
+	}
+
+	if err := ret.UnmarshalStream(bs); err != nil {
+		t.Fatalf("Failed to unmarshal Error: %%s", err)
+	}
+
+	if !reflect.DeepEqual(ret, v) {
+		t.Fatalf("Expected %%+v for value, but %%+v", v, ret)
+	}
+
+	if bs.Len() != 0 {
+		t.Fatalf("Expected %%d for len, but %%d", 0, bs.Len())
+	}
+
+	out := binary.NewStream()
+	start := out.Mark()
+	allocs := testing.AllocsPerRun(100, func() {
+		out.Rewind(start)
+		v.MarshalStream(out)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %%d allocs for MarshalStream, but %%.0f", 0, allocs)
+	}
+`, name, order)
+
+		if strs >= 0 {
+			gen.printf(`
+	in := binary.NewStreamBytes(exp)
+	allocs = testing.AllocsPerRun(100, func() {
+		in.Seek(0, io.SeekStart)
+		ret.UnmarshalStream(in)
+	})
+
+	if allocs > %[1]d {
+		t.Fatalf("Expected at most %%d allocs for UnmarshalStream, but %%.0f", %[1]d, allocs)
+	}
+`, strs)
+		}
+
+		gen.printf("}\n")
+	}
+
+	for _, name := range names {
+		fields, err := gen.fields(name)
+		if err != nil {
+			return nil, err
+		}
+
+		gen.printf("\nfunc testFill%s(v *%s) {\n", name, name)
+
+		var lens []string
+		for _, f := range fields {
+			err = gen.fill("v."+f.name, f.typ, f.tag, 0)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %s", name, f.name, err)
+			}
+
+			if f.tag.Len != "" {
+				lens = append(lens, f.tag.Len)
+			}
+		}
+
+		for _, ln := range lens {
+			gen.printf("v.%s = 2\n", ln)
+		}
+
+		gen.printf("}\n")
+	}
+
+	return format.Source(gen.buf.Bytes())
+}
+
+// countStrings returns count of strings in the type
+// It returns -1 if slices or arrays have strings because the count depends on the length.
+func (gen *generator) countStrings(typ ast.Expr) (int, error) {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if _, ok := gen.structs[t.Name]; ok {
+			fields, err := gen.fields(t.Name)
+			if err != nil {
+				return 0, err
+			}
+
+			count := 0
+			for _, f := range fields {
+				n, err := gen.countStrings(f.typ)
+				if err != nil || n < 0 {
+					return n, err
+				}
+
+				count += n
+			}
+
+			return count, nil
+		}
+
+		if basic, _ := gen.basic(t.Name); basic == "string" {
+			return 1, nil
+		}
+	case *ast.ArrayType:
+		n, err := gen.countStrings(t.Elt)
+		if err != nil || n == 0 {
+			return n, err
+		}
+
+		return -1, nil
+	}
+
+	return 0, nil
+}
+
+// fill writes assignments of test values
+func (gen *generator) fill(expr string, typ ast.Expr, tag binary.Tag, depth int) error {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		if _, ok := gen.structs[t.Name]; ok {
+			gen.printf("testFill%s(&%s)\n", t.Name, expr)
+
+			return nil
+		}
+
+		basic, ok := gen.basic(t.Name)
+		if !ok {
+			return fmt.Errorf("unsupported type %s", t.Name)
+		}
+
+		gen.seq++
+
+		var value string
+		switch basic {
+		case "bool":
+			value = "true"
+		case "string":
+			s := "value" + strconv.Itoa(gen.seq)
+			if tag.Fixed > 0 && len(s) > tag.Fixed {
+				s = s[:tag.Fixed]
+			}
+
+			value = strconv.Quote(s)
+		case "float32", "float64":
+			value = strconv.Itoa(gen.seq) + ".5"
+		case "byte", "uint8", "uint16", "uint32", "uint64":
+			value = strconv.Itoa(gen.seq%100 + 1)
+		default: // signed
+			value = "-" + strconv.Itoa(gen.seq%100+1)
+		}
+
+		gen.printf("%s = %s\n", expr, value)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			gen.printf("%s = make(%s, 2)\n", expr, types.ExprString(t))
+		}
+
+		idx := "i" + strconv.Itoa(depth)
+
+		gen.printf("for %s := range %s {\n", idx, expr)
+		err := gen.fill(expr+"["+idx+"]", t.Elt, tag, depth+1)
+		if err != nil {
+			return err
+		}
+
+		gen.printf("}\n")
+	default:
+		return fmt.Errorf("unsupported type %s", types.ExprString(typ))
+	}
+
+	return nil
+}
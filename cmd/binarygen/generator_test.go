@@ -0,0 +1,84 @@
+package main
+
+/*
+	Binarygen
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"testing"
+)
+
+const testDir = "../../internal/gentest"
+
+var testTypes = []string{"Packet", "Position", "Records", "Entity"}
+
+// TestGenerateUpToDate checks generated files of gentest are up to date
+func TestGenerateUpToDate(t *testing.T) {
+	p, err := parsePackage(testDir)
+	if err != nil {
+		t.Fatalf("Failed to parse package Error: %s", err)
+	}
+
+	gen := newGenerator(p, true)
+
+	src, err := gen.Generate(testTypes)
+	if err != nil {
+		t.Fatalf("Failed to generate Error: %s", err)
+	}
+
+	exp, err := ioutil.ReadFile(testDir + "/packet_binary.go")
+	if err != nil {
+		t.Fatalf("Failed to read file Error: %s", err)
+	}
+
+	if !bytes.Equal(src, exp) {
+		t.Fatalf("packet_binary.go is out of date, run go generate")
+	}
+
+	src, err = gen.GenerateTests(testTypes)
+	if err != nil {
+		t.Fatalf("Failed to generate tests Error: %s", err)
+	}
+
+	exp, err = ioutil.ReadFile(testDir + "/packet_binary_test.go")
+	if err != nil {
+		t.Fatalf("Failed to read file Error: %s", err)
+	}
+
+	if !bytes.Equal(src, exp) {
+		t.Fatalf("packet_binary_test.go is out of date, run go generate")
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	tests := []string{
+		"type T struct { A int }",
+		"type T struct { A int16 `binary:\"varint\"` }",
+		"type T struct { A string `binary:\"prefix=long\"` }",
+		"type T struct { A map[string]byte }",
+		"type T struct { N byte; A string `binary:\"len=N\"` }",
+		"type T struct { N byte; A [2]byte `binary:\"len=N\"` }",
+	}
+
+	for _, test := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), "test.go", "package test\n"+test, 0)
+		if err != nil {
+			t.Fatalf("Failed to parse Error: %s", err)
+		}
+
+		gen := newGenerator(&pkg{name: "test", files: []*ast.File{file}}, false)
+		if _, err := gen.Generate([]string{"T"}); err == nil {
+			t.Fatalf("Expected an error for %s, but nil", test)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+	Binarygen
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+// Binarygen generates MarshalStream and UnmarshalStream methods for tagged structs.
+//
+// The methods use the accessors of binary.Stream without reflection,
+// and struct tags are the same as binary.Marshal and binary.Unmarshal.
+// Round-trip tests are generated too.
+//
+// Usage:
+//
+//	//go:generate go run github.com/beito123/binary/cmd/binarygen -type Packet,Position -order little
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct type names; must be set")
+	order     = flag.String("order", "big", "default byte order of fields; big or little")
+	output    = flag.String("output", "", "output file name; default srcdir/<file>_binary.go")
+	tests     = flag.Bool("tests", true, "generate round-trip tests into <output>_test.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of binarygen:\n")
+	fmt.Fprintf(os.Stderr, "\tbinarygen -type T[,T...] [flags] [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" || (*order != "big" && *order != "little") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	err := run(dir, strings.Split(*typeNames, ","), *order == "little", *output, *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "binarygen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, types []string, little bool, out string, tests bool) error {
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		base := strings.ToLower(types[0])
+		if file := os.Getenv("GOFILE"); file != "" {
+			base = strings.TrimSuffix(file, ".go")
+		}
+
+		out = filepath.Join(dir, base+"_binary.go")
+	}
+
+	gen := newGenerator(pkg, little)
+
+	src, err := gen.Generate(types)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(out, src, 0644)
+	if err != nil {
+		return err
+	}
+
+	if !tests {
+		return nil
+	}
+
+	src, err = gen.GenerateTests(types)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(strings.TrimSuffix(out, ".go")+"_test.go", src, 0644)
+}
+
+// parsePackage parses non-test Go files in dir
+func parsePackage(dir string) (*pkg, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pkg{}
+	fset := token.NewFileSet()
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_binary.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.name != "" && p.name != file.Name.Name {
+			return nil, fmt.Errorf("multiple packages in %s", dir)
+		}
+
+		p.name = file.Name.Name
+		p.files = append(p.files, file)
+	}
+
+	if len(p.files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	return p, nil
+}
+
+// pkg is a parsed package
+type pkg struct {
+	name  string
+	files []*ast.File
+}
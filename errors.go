@@ -0,0 +1,47 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DecodeError is an error occurred decoding a value
+// It matches the underlying error such as ErrNotEnought with errors.Is
+type DecodeError struct {
+	Off   int    // offset of the stream, or -1 if unknown
+	Type  string // name of the decoded type
+	Need  int    // bytes needed
+	Avail int    // bytes available
+	Err   error  // underlying error
+}
+
+func (e *DecodeError) Error() string {
+	s := "binary: reading " + e.Type
+	if e.Off >= 0 {
+		s += " at offset " + strconv.Itoa(e.Off)
+	}
+
+	s += ": " + strings.TrimPrefix(e.Err.Error(), "binary: ")
+	switch e.Err {
+	case ErrNotEnought:
+		s += " (need " + strconv.Itoa(e.Need) + ", available " + strconv.Itoa(e.Avail) + ")"
+	case ErrTrailingBytes:
+		s += " (" + strconv.Itoa(e.Avail) + " bytes left)"
+	}
+
+	return s
+}
+
+// Unwrap returns the underlying error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,78 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bytes"
+	"errors"
+)
+
+var (
+	// ErrTrailingBytes is returned when bytes are left after decoding
+	ErrTrailingBytes = errors.New("binary: trailing bytes")
+
+	// ErrUnexpected is returned when bytes don't match expected bytes
+	ErrUnexpected = errors.New("binary: unexpected bytes")
+)
+
+// ExpectEnd returns an error if bytes are left
+func (bs *Stream) ExpectEnd() error {
+	if !bs.correct {
+		return bs.err
+	}
+
+	if bs.Len() > 0 {
+		return bs.fail("End", 0, ErrTrailingBytes)
+	}
+
+	return nil
+}
+
+// Expect gets bytes and returns an error if they don't match b
+// It neither moves offset nor records the error if they don't match like Peek,
+// so other bytes can be tried after it.
+func (bs *Stream) Expect(b []byte) error {
+	if !bs.correct {
+		return bs.err
+	}
+
+	got, err := bs.at("Expect", bs.off, len(b))
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(got, b) {
+		return &DecodeError{Off: bs.off, Type: "Expect", Need: len(b), Avail: bs.Len(), Err: ErrUnexpected}
+	}
+
+	bs.off += len(b)
+
+	return nil
+}
+
+// ExpectByte gets a byte and returns an error if it isn't c
+func (bs *Stream) ExpectByte(c byte) error {
+	if !bs.correct {
+		return bs.err
+	}
+
+	got, err := bs.at("Expect", bs.off, 1)
+	if err != nil {
+		return err
+	}
+
+	if got[0] != c {
+		return &DecodeError{Off: bs.off, Type: "Expect", Need: 1, Avail: bs.Len(), Err: ErrUnexpected}
+	}
+
+	bs.off++
+
+	return nil
+}
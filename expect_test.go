@@ -0,0 +1,102 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStreamExpect(t *testing.T) {
+	stream := NewStreamBytes([]byte{0xfe, 0xca, 0x01, 0x02})
+
+	err := stream.Expect([]byte{0xfe, 0xca})
+	if err != nil {
+		t.Fatalf("Failed to expect bytes Error: %s", err)
+	}
+
+	err = stream.ExpectByte(0x01)
+	if err != nil {
+		t.Fatalf("Failed to expect a byte Error: %s", err)
+	}
+
+	err = stream.ExpectByte(0x03)
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || derr.Off != 3 || !errors.Is(err, ErrUnexpected) {
+		t.Fatalf("Expected %v at offset %d, but %v", ErrUnexpected, 3, err)
+	}
+
+	if stream.Off() != 3 {
+		t.Fatalf("Expected %d for offset, but %d", 3, stream.Off())
+	}
+
+	// a mismatch isn't recorded, so other bytes can be tried
+	if stream.Err() != nil {
+		t.Fatalf("Expected no error, but %v", stream.Err())
+	}
+
+	err = stream.ExpectByte(0x02)
+	if err != nil {
+		t.Fatalf("Failed to expect a byte Error: %s", err)
+	}
+
+	err = stream.ExpectByte(0x02)
+	if !errors.Is(err, ErrNotEnought) || stream.Err() != nil {
+		t.Fatalf("Expected %v without recording, but %v", ErrNotEnought, err)
+	}
+}
+
+func TestStreamExpectEnd(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x01, 0x02, 0x03})
+	stream.Byte()
+
+	err := stream.ExpectEnd()
+	if !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("Expected %v, but %v", ErrTrailingBytes, err)
+	}
+
+	msg := "binary: reading End at offset 1: trailing bytes (2 bytes left)"
+	if err.Error() != msg {
+		t.Fatalf("Expected %s for message, but %s", msg, err.Error())
+	}
+
+	stream = NewStreamBytes([]byte{0x01})
+	stream.Byte()
+
+	err = stream.ExpectEnd()
+	if err != nil {
+		t.Fatalf("Expected no error, but %v", err)
+	}
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	var v struct {
+		A uint16
+	}
+
+	err := UnmarshalStrict([]byte{0x00, 0x01}, BigEndian, &v)
+	if err != nil || v.A != 1 {
+		t.Fatalf("Expected %d for value, but %d Error: %v", 1, v.A, err)
+	}
+
+	err = UnmarshalStrict([]byte{0x00, 0x01, 0x02}, BigEndian, &v)
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || derr.Off != 2 || !errors.Is(err, ErrTrailingBytes) {
+		t.Fatalf("Expected %v at offset %d, but %v", ErrTrailingBytes, 2, err)
+	}
+
+	// Unmarshal ignores trailing bytes
+	err = Unmarshal([]byte{0x00, 0x01, 0x02}, BigEndian, &v)
+	if err != nil {
+		t.Fatalf("Expected no error, but %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"reflect"
+)
+
+// Fixed is a constraint of fixed size numeric types
+// Named types such as type EntityID uint64 are also allowed.
+type Fixed interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~int64 | ~uint64 | ~float32 | ~float64
+}
+
+// typeOf returns the type of T without boxing a value of T
+func typeOf[T Fixed]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// SizeOf returns byte size of T
+func SizeOf[T Fixed]() int {
+	return int(typeOf[T]().Size())
+}
+
+// nameOf returns name of T
+func nameOf[T Fixed]() string {
+	return typeOf[T]().String()
+}
+
+// decode returns a value of T from b with the order
+// b must have SizeOf[T]() bytes
+func decode[T Fixed](b []byte, o Order) T {
+	switch typeOf[T]().Kind() {
+	case reflect.Int8, reflect.Uint8:
+		return T(o.Byte(b))
+	case reflect.Int16, reflect.Uint16:
+		return T(o.UShort(b))
+	case reflect.Int32, reflect.Uint32:
+		return T(o.UInt(b))
+	case reflect.Int64, reflect.Uint64:
+		return T(o.ULong(b))
+	case reflect.Float32:
+		return T(o.Float(b))
+	case reflect.Float64:
+		return T(o.Double(b))
+	}
+
+	return 0
+}
+
+// AppendOrder appends v to dst with the order and returns the extended buffer
+func AppendOrder[T Fixed](dst []byte, o Order, v T) []byte {
+	// signed values are converted to unsigned ones of the same size, which keeps the bits
+	switch typeOf[T]().Kind() {
+	case reflect.Int8, reflect.Uint8:
+		return o.AppendByte(dst, uint8(v))
+	case reflect.Int16, reflect.Uint16:
+		return o.AppendUShort(dst, uint16(v))
+	case reflect.Int32, reflect.Uint32:
+		return o.AppendUInt(dst, uint32(v))
+	case reflect.Int64, reflect.Uint64:
+		return o.AppendULong(dst, uint64(v))
+	case reflect.Float32:
+		return o.AppendFloat(dst, float32(v))
+	case reflect.Float64:
+		return o.AppendDouble(dst, float64(v))
+	}
+
+	return dst
+}
+
+// AppendOrderSlice appends values of v to dst with the order and returns the extended buffer
+func AppendOrderSlice[T Fixed](dst []byte, o Order, v []T) []byte {
+	for _, x := range v {
+		dst = AppendOrder(dst, o, x)
+	}
+
+	return dst
+}
+
+// Get gets a value of T from the stream with the order
+func Get[T Fixed](s *Stream, o Order) (T, error) {
+	b, err := s.get(nameOf[T](), SizeOf[T]())
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	return decode[T](b, o), nil
+}
+
+// Put puts a value of T to the stream with the order
+func Put[T Fixed](s *Stream, o Order, v T) error {
+	s.buf = AppendOrder(s.buf, o, v)
+
+	return nil
+}
+
+// GetSlice gets n values of T from the stream with the order
+func GetSlice[T Fixed](s *Stream, o Order, n int) ([]T, error) {
+	size := SizeOf[T]()
+	if n < 0 || (n > 0 && n > s.Len()/size) {
+		return nil, s.fail("[]"+nameOf[T](), n*size, ErrNotEnought)
+	}
+
+	b, err := s.get("[]"+nameOf[T](), n*size)
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]T, n)
+	for i := range v {
+		v[i] = decode[T](b[i*size:], o)
+	}
+
+	return v, nil
+}
+
+// PutSlice puts values of T to the stream with the order
+func PutSlice[T Fixed](s *Stream, o Order, v []T) error {
+	s.buf = AppendOrderSlice(s.buf, o, v)
+
+	return nil
+}
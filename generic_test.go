@@ -0,0 +1,141 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestGenericGetPut(t *testing.T) {
+	stream := NewStream()
+
+	Put[int32](stream, BigEndian, -2)
+	Put(stream, LittleEndian, uint16(0x0102))
+	Put(stream, LittleEndian, float64(1))
+
+	exp := []byte{0xff, 0xff, 0xff, 0xfe, 0x02, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f}
+	ret := stream.Bytes()
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	i, err := Get[int32](stream, BigEndian)
+	if err != nil || i != -2 {
+		t.Fatalf("Expected %d for int32, but %d Error: %v", -2, i, err)
+	}
+
+	s, err := Get[uint16](stream, LittleEndian)
+	if err != nil || s != 0x0102 {
+		t.Fatalf("Expected %d for uint16, but %d Error: %v", 0x0102, s, err)
+	}
+
+	d, err := Get[float64](stream, LittleEndian)
+	if err != nil || d != 1 {
+		t.Fatalf("Expected %f for float64, but %f Error: %v", 1.0, d, err)
+	}
+
+	_, err = Get[int64](stream, BigEndian)
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || derr.Type != "int64" || !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected a DecodeError for int64, but %v", err)
+	}
+}
+
+func TestGenericSlice(t *testing.T) {
+	exp := []int16{1, -1, 256}
+
+	stream := NewStream()
+	PutSlice(stream, LittleEndian, exp)
+
+	data := []byte{0x01, 0x00, 0xff, 0xff, 0x00, 0x01}
+	if !bytes.Equal(stream.Bytes(), data) {
+		t.Fatalf("Expected %d for bytes, but %d", data, stream.Bytes())
+	}
+
+	ret, err := GetSlice[int16](stream, LittleEndian, len(exp))
+	if err != nil {
+		t.Fatalf("Failed to get slice Error: %s", err)
+	}
+
+	for i := range exp {
+		if ret[i] != exp[i] {
+			t.Fatalf("Expected %d for slice, but %d", exp, ret)
+		}
+	}
+
+	stream.SetBytes(data)
+	if _, err := GetSlice[int16](stream, LittleEndian, 4); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for short slice, but %v", ErrNotEnought, err)
+	}
+}
+
+func TestAppendOrder(t *testing.T) {
+	ret := AppendOrder([]byte{0xaa}, BigEndian, uint32(0x01020304))
+	ret = AppendOrderSlice(ret, LittleEndian, []uint16{0x0506})
+
+	exp := []byte{0xaa, 0x01, 0x02, 0x03, 0x04, 0x06, 0x05}
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	if size := SizeOf[float32](); size != FloatSize {
+		t.Fatalf("Expected %d for size, but %d", FloatSize, size)
+	}
+}
+
+type testEntityID uint64
+
+func TestGenericNamed(t *testing.T) {
+	stream := NewStream()
+
+	Put(stream, BigEndian, testEntityID(0x0102030405060708))
+
+	exp := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	if !bytes.Equal(stream.Bytes(), exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, stream.Bytes())
+	}
+
+	id, err := Get[testEntityID](stream, BigEndian)
+	if err != nil || id != 0x0102030405060708 {
+		t.Fatalf("Expected %d for id, but %d Error: %v", 0x0102030405060708, id, err)
+	}
+
+	_, err = Get[testEntityID](stream, BigEndian)
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || derr.Type != "binary.testEntityID" {
+		t.Fatalf("Expected a DecodeError for binary.testEntityID, but %v", err)
+	}
+}
+
+func TestGenericAllocs(t *testing.T) {
+	buf := make([]byte, 0, 8)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendOrder(buf[:0], LittleEndian, testEntityID(0x0102030405060708))
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %d allocs for AppendOrder, but %.0f", 0, allocs)
+	}
+
+	stream := NewStreamBytes(buf)
+	allocs = testing.AllocsPerRun(100, func() {
+		stream.Seek(0, io.SeekStart)
+		Get[float64](stream, LittleEndian)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %d allocs for Get, but %.0f", 0, allocs)
+	}
+}
@@ -0,0 +1,59 @@
+package gentest
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+//go:generate go run ../../cmd/binarygen -type Packet,Position,Records,Entity -order little
+
+// EntityID is an entity runtime id
+type EntityID uint64
+
+// Position is a position of an entity
+type Position struct {
+	X float32
+	Y float32 `binary:"big"`
+	Z float64
+}
+
+// Packet is a test packet for generated codes
+type Packet struct {
+	ID        byte
+	Sequence  uint32   `binary:"triad"`
+	Entity    EntityID `binary:"varint"`
+	Delta     int32    `binary:"varint"`
+	Name      string   `binary:"prefix=short"`
+	Tag       string   `binary:"cstring"`
+	Code      string   `binary:"fixed=4"`
+	Flags     uint16   `binary:"skip=2"`
+	Position  Position
+	Count     byte
+	Items     []int16 `binary:"len=Count"`
+	Payload   []byte  `binary:"prefix=byte"`
+	Hash      [2]uint16
+	Magic     [4]byte
+	Path      []Position `binary:"prefix=lshort"`
+	Long      int64      `binary:"big"`
+	OK        bool
+	Ignored   int `binary:"-"`
+	unexposed int
+}
+
+// Records is a test type with a signed len field
+type Records struct {
+	Count  int8
+	Values []uint16 `binary:"len=Count"`
+}
+
+// Entity is a test type with struct fields in the other order
+type Entity struct {
+	ID       EntityID   `binary:"varint"`
+	Position Position   `binary:"big"`
+	Path     []Position `binary:"big,prefix=byte"`
+	Last     Position   `binary:"little"`
+}
@@ -0,0 +1,435 @@
+// Code generated by binarygen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"github.com/beito123/binary"
+)
+
+// MarshalStream encodes Packet to the stream
+func (v *Packet) MarshalStream(bs *binary.Stream) error {
+	if err := bs.PutByte(v.ID); err != nil {
+		return err
+	}
+
+	if err := bs.PutLUTriad(v.Sequence); err != nil {
+		return err
+	}
+
+	if err := bs.PutUVarLong(uint64(v.Entity)); err != nil {
+		return err
+	}
+
+	if err := bs.PutVarInt(v.Delta); err != nil {
+		return err
+	}
+
+	if err := bs.PutString(binary.PrefixShort, v.Name); err != nil {
+		return err
+	}
+
+	if err := bs.PutCString(v.Tag); err != nil {
+		return err
+	}
+
+	if err := bs.PutFixedString(4, v.Code); err != nil {
+		return err
+	}
+
+	if err := bs.Pad(2); err != nil {
+		return err
+	}
+	if err := bs.PutLShort(v.Flags); err != nil {
+		return err
+	}
+
+	if err := v.Position.MarshalStream(bs); err != nil {
+		return err
+	}
+
+	if err := bs.PutByte(v.Count); err != nil {
+		return err
+	}
+
+	if len(v.Items) != int(v.Count) {
+		return &binary.FieldError{Struct: "Packet", Field: "Items", Err: binary.ErrLenMismatch}
+	}
+	for i0 := range v.Items {
+		if err := bs.PutLSShort(v.Items[i0]); err != nil {
+			return err
+		}
+	}
+
+	if err := bs.PutLength(binary.PrefixByte, len(v.Payload)); err != nil {
+		return err
+	}
+	if err := bs.Put(v.Payload); err != nil {
+		return err
+	}
+
+	for i0 := range v.Hash {
+		if err := bs.PutLShort(v.Hash[i0]); err != nil {
+			return err
+		}
+	}
+
+	if err := bs.Put(v.Magic[:]); err != nil {
+		return err
+	}
+
+	if err := bs.PutLength(binary.PrefixLShort, len(v.Path)); err != nil {
+		return err
+	}
+	for i0 := range v.Path {
+		if err := v.Path[i0].MarshalStream(bs); err != nil {
+			return err
+		}
+	}
+
+	if err := bs.PutLong(v.Long); err != nil {
+		return err
+	}
+
+	if err := bs.PutBool(v.OK); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalStream decodes Packet from the stream
+func (v *Packet) UnmarshalStream(bs *binary.Stream) error {
+	var err error
+
+	if v.ID, err = bs.Byte(); err != nil {
+		return err
+	}
+
+	if v.Sequence, err = bs.LUTriad(); err != nil {
+		return err
+	}
+
+	{
+		x, err := bs.UVarLong()
+		if err != nil {
+			return err
+		}
+
+		v.Entity = EntityID(x)
+	}
+
+	if v.Delta, err = bs.VarInt(); err != nil {
+		return err
+	}
+
+	if v.Name, err = bs.String(binary.PrefixShort); err != nil {
+		return err
+	}
+
+	if v.Tag, err = bs.CString(); err != nil {
+		return err
+	}
+
+	if v.Code, err = bs.FixedString(4); err != nil {
+		return err
+	}
+
+	if bs.Len() < 2 {
+		return bs.Fail("skip", 2, binary.ErrNotEnought)
+	}
+	bs.Skip(2)
+	if v.Flags, err = bs.LShort(); err != nil {
+		return err
+	}
+
+	if err := v.Position.UnmarshalStream(bs); err != nil {
+		return err
+	}
+
+	if v.Count, err = bs.Byte(); err != nil {
+		return err
+	}
+
+	{
+		n0 := int(v.Count)
+		if n0 < 0 {
+			return bs.Fail("[]int16", 0, binary.ErrNegativeLen)
+		}
+
+		if bs.MaxLen() > 0 && n0 > bs.MaxLen() {
+			return bs.Fail("[]int16", n0, binary.ErrTooLong)
+		}
+
+		if n0 > bs.Len()/2 {
+			return bs.Fail("[]int16", n0*2, binary.ErrNotEnought)
+		}
+
+		if cap(v.Items) < n0 {
+			v.Items = make([]int16, n0)
+		} else {
+			v.Items = v.Items[:n0]
+		}
+
+		for i0 := range v.Items {
+			if v.Items[i0], err = bs.LSShort(); err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		n0, err := bs.Length(binary.PrefixByte)
+		if err != nil {
+			return err
+		}
+
+		if n0 > bs.Len() {
+			return bs.Fail("[]uint8", n0, binary.ErrNotEnought)
+		}
+
+		v.Payload = append(v.Payload[:0], bs.Get(n0)...)
+	}
+
+	for i0 := range v.Hash {
+		if v.Hash[i0], err = bs.LShort(); err != nil {
+			return err
+		}
+	}
+
+	if len(v.Magic) > bs.Len() {
+		return bs.Fail("[4]uint8", len(v.Magic), binary.ErrNotEnought)
+	}
+	copy(v.Magic[:], bs.Get(len(v.Magic)))
+
+	{
+		n0, err := bs.Length(binary.PrefixLShort)
+		if err != nil {
+			return err
+		}
+
+		if n0 > bs.Len()/16 {
+			return bs.Fail("[]gentest.Position", n0*16, binary.ErrNotEnought)
+		}
+
+		if cap(v.Path) < n0 {
+			v.Path = make([]Position, n0)
+		} else {
+			v.Path = v.Path[:n0]
+		}
+
+		for i0 := range v.Path {
+			if err := v.Path[i0].UnmarshalStream(bs); err != nil {
+				return err
+			}
+		}
+	}
+
+	if v.Long, err = bs.Long(); err != nil {
+		return err
+	}
+
+	if v.OK, err = bs.Bool(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalStream encodes Position to the stream
+func (v *Position) MarshalStream(bs *binary.Stream) error {
+	if err := bs.PutLFloat(v.X); err != nil {
+		return err
+	}
+
+	if err := bs.PutFloat(v.Y); err != nil {
+		return err
+	}
+
+	if err := bs.PutLDouble(v.Z); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalStream decodes Position from the stream
+func (v *Position) UnmarshalStream(bs *binary.Stream) error {
+	var err error
+
+	if v.X, err = bs.LFloat(); err != nil {
+		return err
+	}
+
+	if v.Y, err = bs.Float(); err != nil {
+		return err
+	}
+
+	if v.Z, err = bs.LDouble(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalStream encodes Records to the stream
+func (v *Records) MarshalStream(bs *binary.Stream) error {
+	if err := bs.PutSByte(v.Count); err != nil {
+		return err
+	}
+
+	if len(v.Values) != int(v.Count) {
+		return &binary.FieldError{Struct: "Records", Field: "Values", Err: binary.ErrLenMismatch}
+	}
+	for i0 := range v.Values {
+		if err := bs.PutLShort(v.Values[i0]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalStream decodes Records from the stream
+func (v *Records) UnmarshalStream(bs *binary.Stream) error {
+	var err error
+
+	if v.Count, err = bs.SByte(); err != nil {
+		return err
+	}
+
+	{
+		n0 := int(v.Count)
+		if n0 < 0 {
+			return bs.Fail("[]uint16", 0, binary.ErrNegativeLen)
+		}
+
+		if bs.MaxLen() > 0 && n0 > bs.MaxLen() {
+			return bs.Fail("[]uint16", n0, binary.ErrTooLong)
+		}
+
+		if n0 > bs.Len()/2 {
+			return bs.Fail("[]uint16", n0*2, binary.ErrNotEnought)
+		}
+
+		if cap(v.Values) < n0 {
+			v.Values = make([]uint16, n0)
+		} else {
+			v.Values = v.Values[:n0]
+		}
+
+		for i0 := range v.Values {
+			if v.Values[i0], err = bs.LShort(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalStream encodes Entity to the stream
+func (v *Entity) MarshalStream(bs *binary.Stream) error {
+	if err := bs.PutUVarLong(uint64(v.ID)); err != nil {
+		return err
+	}
+
+	if err := v.Position.marshalStreamBig(bs); err != nil {
+		return err
+	}
+
+	if err := bs.PutLength(binary.PrefixByte, len(v.Path)); err != nil {
+		return err
+	}
+	for i0 := range v.Path {
+		if err := v.Path[i0].marshalStreamBig(bs); err != nil {
+			return err
+		}
+	}
+
+	if err := v.Last.MarshalStream(bs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalStream decodes Entity from the stream
+func (v *Entity) UnmarshalStream(bs *binary.Stream) error {
+	{
+		x, err := bs.UVarLong()
+		if err != nil {
+			return err
+		}
+
+		v.ID = EntityID(x)
+	}
+
+	if err := v.Position.unmarshalStreamBig(bs); err != nil {
+		return err
+	}
+
+	{
+		n0, err := bs.Length(binary.PrefixByte)
+		if err != nil {
+			return err
+		}
+
+		if n0 > bs.Len()/16 {
+			return bs.Fail("[]gentest.Position", n0*16, binary.ErrNotEnought)
+		}
+
+		if cap(v.Path) < n0 {
+			v.Path = make([]Position, n0)
+		} else {
+			v.Path = v.Path[:n0]
+		}
+
+		for i0 := range v.Path {
+			if err := v.Path[i0].unmarshalStreamBig(bs); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := v.Last.UnmarshalStream(bs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// marshalStreamBig encodes Position to the stream in big endian
+func (v *Position) marshalStreamBig(bs *binary.Stream) error {
+	if err := bs.PutFloat(v.X); err != nil {
+		return err
+	}
+
+	if err := bs.PutFloat(v.Y); err != nil {
+		return err
+	}
+
+	if err := bs.PutDouble(v.Z); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unmarshalStreamBig decodes Position from the stream in big endian
+func (v *Position) unmarshalStreamBig(bs *binary.Stream) error {
+	var err error
+
+	if v.X, err = bs.Float(); err != nil {
+		return err
+	}
+
+	if v.Y, err = bs.Float(); err != nil {
+		return err
+	}
+
+	if v.Z, err = bs.Double(); err != nil {
+		return err
+	}
+
+	return nil
+}
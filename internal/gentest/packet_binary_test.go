@@ -0,0 +1,279 @@
+// Code generated by binarygen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/beito123/binary"
+)
+
+func TestPacketBinary(t *testing.T) {
+	var v, ret Packet
+	testFillPacket(&v)
+
+	bs := binary.NewStream()
+	if err := v.MarshalStream(bs); err != nil {
+		t.Fatalf("Failed to marshal Error: %s", err)
+	}
+
+	exp, err := binary.Marshal(binary.LittleEndian, &v)
+	if err != nil {
+		t.Fatalf("Failed to marshal with reflection Error: %s", err)
+	}
+
+	if !bytes.Equal(bs.Bytes(), exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, bs.Bytes())
+	}
+
+	if err := ret.UnmarshalStream(bs); err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(ret, v) {
+		t.Fatalf("Expected %+v for value, but %+v", v, ret)
+	}
+
+	if bs.Len() != 0 {
+		t.Fatalf("Expected %d for len, but %d", 0, bs.Len())
+	}
+
+	out := binary.NewStream()
+	start := out.Mark()
+	allocs := testing.AllocsPerRun(100, func() {
+		out.Rewind(start)
+		v.MarshalStream(out)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %d allocs for MarshalStream, but %.0f", 0, allocs)
+	}
+
+	in := binary.NewStreamBytes(exp)
+	allocs = testing.AllocsPerRun(100, func() {
+		in.Seek(0, io.SeekStart)
+		ret.UnmarshalStream(in)
+	})
+
+	if allocs > 3 {
+		t.Fatalf("Expected at most %d allocs for UnmarshalStream, but %.0f", 3, allocs)
+	}
+}
+
+func TestPositionBinary(t *testing.T) {
+	var v, ret Position
+	testFillPosition(&v)
+
+	bs := binary.NewStream()
+	if err := v.MarshalStream(bs); err != nil {
+		t.Fatalf("Failed to marshal Error: %s", err)
+	}
+
+	exp, err := binary.Marshal(binary.LittleEndian, &v)
+	if err != nil {
+		t.Fatalf("Failed to marshal with reflection Error: %s", err)
+	}
+
+	if !bytes.Equal(bs.Bytes(), exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, bs.Bytes())
+	}
+
+	if err := ret.UnmarshalStream(bs); err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(ret, v) {
+		t.Fatalf("Expected %+v for value, but %+v", v, ret)
+	}
+
+	if bs.Len() != 0 {
+		t.Fatalf("Expected %d for len, but %d", 0, bs.Len())
+	}
+
+	out := binary.NewStream()
+	start := out.Mark()
+	allocs := testing.AllocsPerRun(100, func() {
+		out.Rewind(start)
+		v.MarshalStream(out)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %d allocs for MarshalStream, but %.0f", 0, allocs)
+	}
+
+	in := binary.NewStreamBytes(exp)
+	allocs = testing.AllocsPerRun(100, func() {
+		in.Seek(0, io.SeekStart)
+		ret.UnmarshalStream(in)
+	})
+
+	if allocs > 0 {
+		t.Fatalf("Expected at most %d allocs for UnmarshalStream, but %.0f", 0, allocs)
+	}
+}
+
+func TestRecordsBinary(t *testing.T) {
+	var v, ret Records
+	testFillRecords(&v)
+
+	bs := binary.NewStream()
+	if err := v.MarshalStream(bs); err != nil {
+		t.Fatalf("Failed to marshal Error: %s", err)
+	}
+
+	exp, err := binary.Marshal(binary.LittleEndian, &v)
+	if err != nil {
+		t.Fatalf("Failed to marshal with reflection Error: %s", err)
+	}
+
+	if !bytes.Equal(bs.Bytes(), exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, bs.Bytes())
+	}
+
+	if err := ret.UnmarshalStream(bs); err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(ret, v) {
+		t.Fatalf("Expected %+v for value, but %+v", v, ret)
+	}
+
+	if bs.Len() != 0 {
+		t.Fatalf("Expected %d for len, but %d", 0, bs.Len())
+	}
+
+	out := binary.NewStream()
+	start := out.Mark()
+	allocs := testing.AllocsPerRun(100, func() {
+		out.Rewind(start)
+		v.MarshalStream(out)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %d allocs for MarshalStream, but %.0f", 0, allocs)
+	}
+
+	in := binary.NewStreamBytes(exp)
+	allocs = testing.AllocsPerRun(100, func() {
+		in.Seek(0, io.SeekStart)
+		ret.UnmarshalStream(in)
+	})
+
+	if allocs > 0 {
+		t.Fatalf("Expected at most %d allocs for UnmarshalStream, but %.0f", 0, allocs)
+	}
+}
+
+func TestEntityBinary(t *testing.T) {
+	var v, ret Entity
+	testFillEntity(&v)
+
+	bs := binary.NewStream()
+	if err := v.MarshalStream(bs); err != nil {
+		t.Fatalf("Failed to marshal Error: %s", err)
+	}
+
+	exp, err := binary.Marshal(binary.LittleEndian, &v)
+	if err != nil {
+		t.Fatalf("Failed to marshal with reflection Error: %s", err)
+	}
+
+	if !bytes.Equal(bs.Bytes(), exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, bs.Bytes())
+	}
+
+	if err := ret.UnmarshalStream(bs); err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(ret, v) {
+		t.Fatalf("Expected %+v for value, but %+v", v, ret)
+	}
+
+	if bs.Len() != 0 {
+		t.Fatalf("Expected %d for len, but %d", 0, bs.Len())
+	}
+
+	out := binary.NewStream()
+	start := out.Mark()
+	allocs := testing.AllocsPerRun(100, func() {
+		out.Rewind(start)
+		v.MarshalStream(out)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected %d allocs for MarshalStream, but %.0f", 0, allocs)
+	}
+
+	in := binary.NewStreamBytes(exp)
+	allocs = testing.AllocsPerRun(100, func() {
+		in.Seek(0, io.SeekStart)
+		ret.UnmarshalStream(in)
+	})
+
+	if allocs > 0 {
+		t.Fatalf("Expected at most %d allocs for UnmarshalStream, but %.0f", 0, allocs)
+	}
+}
+
+func testFillPacket(v *Packet) {
+	v.ID = 2
+	v.Sequence = 3
+	v.Entity = 4
+	v.Delta = -5
+	v.Name = "value5"
+	v.Tag = "value6"
+	v.Code = "valu"
+	v.Flags = 9
+	testFillPosition(&v.Position)
+	v.Count = 10
+	v.Items = make([]int16, 2)
+	for i0 := range v.Items {
+		v.Items[i0] = -11
+	}
+	v.Payload = make([]byte, 2)
+	for i0 := range v.Payload {
+		v.Payload[i0] = 12
+	}
+	for i0 := range v.Hash {
+		v.Hash[i0] = 13
+	}
+	for i0 := range v.Magic {
+		v.Magic[i0] = 14
+	}
+	v.Path = make([]Position, 2)
+	for i0 := range v.Path {
+		testFillPosition(&v.Path[i0])
+	}
+	v.Long = -15
+	v.OK = true
+	v.Count = 2
+}
+
+func testFillPosition(v *Position) {
+	v.X = 16.5
+	v.Y = 17.5
+	v.Z = 18.5
+}
+
+func testFillRecords(v *Records) {
+	v.Count = -20
+	v.Values = make([]uint16, 2)
+	for i0 := range v.Values {
+		v.Values[i0] = 21
+	}
+	v.Count = 2
+}
+
+func testFillEntity(v *Entity) {
+	v.ID = 22
+	testFillPosition(&v.Position)
+	v.Path = make([]Position, 2)
+	for i0 := range v.Path {
+		testFillPosition(&v.Path[i0])
+	}
+	testFillPosition(&v.Last)
+}
@@ -0,0 +1,68 @@
+package gentest
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beito123/binary"
+)
+
+func TestUnmarshalStreamError(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"negative count", []byte{0xff, 0x01, 0x00}, binary.ErrNegativeLen},
+		{"short values", []byte{0x03, 0x01, 0x00}, binary.ErrNotEnought},
+	}
+
+	for _, test := range tests {
+		var v Records
+
+		bs := binary.NewStreamBytes(test.data)
+		err := v.UnmarshalStream(bs)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("Expected %v for %s, but %v", test.err, test.name, err)
+		}
+
+		if bs.Err() != err {
+			t.Fatalf("Expected the error is latched for %s, but %v", test.name, bs.Err())
+		}
+
+		var derr, rerr *binary.DecodeError
+		if !errors.As(err, &derr) {
+			t.Fatalf("Expected a DecodeError for %s, but %v", test.name, err)
+		}
+
+		// same as reflection
+		err = binary.Unmarshal(test.data, binary.LittleEndian, &v)
+		if !errors.As(err, &rerr) {
+			t.Fatalf("Expected a DecodeError with reflection for %s, but %v", test.name, err)
+		}
+
+		if *derr != *rerr {
+			t.Fatalf("Expected %+v for %s, but %+v", *rerr, test.name, *derr)
+		}
+	}
+}
+
+func TestMarshalStreamLenMismatch(t *testing.T) {
+	v := Records{Count: 3, Values: []uint16{1, 2}}
+
+	err := v.MarshalStream(binary.NewStream())
+
+	_, rerr := binary.Marshal(binary.LittleEndian, &v)
+	if err == nil || rerr == nil || err.Error() != rerr.Error() || !errors.Is(err, binary.ErrLenMismatch) {
+		t.Fatalf("Expected %v, but %v", rerr, err)
+	}
+}
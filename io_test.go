@@ -0,0 +1,218 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+var (
+	_ io.ReadWriteSeeker = &Stream{}
+	_ io.ReaderAt        = &Stream{}
+	_ io.WriterAt        = &Stream{}
+	_ io.ByteScanner     = &Stream{}
+	_ io.RuneReader      = &Stream{}
+	_ io.WriterTo        = &Stream{}
+	_ io.ReaderFrom      = &Stream{}
+)
+
+func TestStreamIOReader(t *testing.T) {
+	content := []byte("Hello, binary stream! こんにちは")
+
+	err := iotest.TestReader(NewStreamBytes(content), content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = iotest.TestReader(NewStream(), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamSeek(t *testing.T) {
+	stream := NewStreamBytes(Magic)
+
+	off, err := stream.Seek(4, io.SeekStart)
+	if err != nil || off != 4 {
+		t.Fatalf("Expected %d for offset, but %d Error: %v", 4, off, err)
+	}
+
+	val, _ := stream.Byte()
+	if val != 0x04 {
+		t.Fatalf("Expected %d for byte, but %d", 0x04, val)
+	}
+
+	_, err = stream.Seek(1, io.SeekEnd)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidOffset, err)
+	}
+
+	_, err = stream.Seek(-6, io.SeekCurrent)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidOffset, err)
+	}
+
+	_, err = stream.Seek(0, 3)
+	if !errors.Is(err, ErrInvalidWhence) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidWhence, err)
+	}
+
+	if stream.Off() != 5 {
+		t.Fatalf("Expected %d for offset, but %d", 5, stream.Off())
+	}
+}
+
+func TestStreamSeekErr(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x01, 0x02, 0x03})
+
+	stream.Byte()
+	stream.Int() // fails
+
+	if _, err := stream.Seek(0, io.SeekStart); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for seek after a failure, but %v", ErrNotEnought, err)
+	}
+
+	if err := stream.UnreadByte(); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for unread after a failure, but %v", ErrNotEnought, err)
+	}
+
+	if stream.Off() != 1 {
+		t.Fatalf("Expected %d for offset, but %d", 1, stream.Off())
+	}
+}
+
+func TestStreamWriteAt(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x00, 0x01, 0x02})
+
+	_, err := stream.WriteAt([]byte{0x0a, 0x0b}, 1)
+	if err != nil {
+		t.Fatalf("Failed to write bytes Error: %s", err)
+	}
+
+	_, err = stream.WriteAt([]byte{0x0c}, 5)
+	if err != nil {
+		t.Fatalf("Failed to write bytes Error: %s", err)
+	}
+
+	exp := []byte{0x00, 0x0a, 0x0b, 0x00, 0x00, 0x0c}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	_, err = stream.WriteAt([]byte{0x00}, -1)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidOffset, err)
+	}
+}
+
+func TestStreamByteScanner(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x01, 0x02})
+
+	err := stream.UnreadByte()
+	if !errors.Is(err, ErrUnreadByte) {
+		t.Fatalf("Expected %v, but %v", ErrUnreadByte, err)
+	}
+
+	c, _ := stream.ReadByte()
+	stream.UnreadByte()
+	c2, _ := stream.ReadByte()
+	if c != 0x01 || c2 != 0x01 {
+		t.Fatalf("Expected %d for byte, but %d and %d", 0x01, c, c2)
+	}
+
+	stream.ReadByte()
+
+	_, err = stream.ReadByte()
+	if err != io.EOF {
+		t.Fatalf("Expected %v, but %v", io.EOF, err)
+	}
+}
+
+func TestStreamReadRune(t *testing.T) {
+	stream := NewStreamBytes([]byte("aあ"))
+
+	for _, exp := range []struct {
+		r    rune
+		size int
+	}{{'a', 1}, {'あ', 3}} {
+		r, size, err := stream.ReadRune()
+		if err != nil || r != exp.r || size != exp.size {
+			t.Fatalf("Expected %q (%d) for rune, but %q (%d) Error: %v", exp.r, exp.size, r, size, err)
+		}
+	}
+
+	_, _, err := stream.ReadRune()
+	if err != io.EOF {
+		t.Fatalf("Expected %v, but %v", io.EOF, err)
+	}
+}
+
+func TestStreamCopy(t *testing.T) {
+	content := bytes.Repeat(Magic, 100)
+
+	// ReadFrom with a reader returning bytes one by one
+	stream := NewStream()
+	n, err := io.Copy(stream, iotest.OneByteReader(bytes.NewReader(content)))
+	if err != nil || n != int64(len(content)) {
+		t.Fatalf("Expected %d for copied bytes, but %d Error: %v", len(content), n, err)
+	}
+
+	stream.Skip(3)
+
+	// WriteTo
+	var buf bytes.Buffer
+	n, err = io.Copy(&buf, stream)
+	if err != nil || n != int64(len(content)-3) {
+		t.Fatalf("Expected %d for copied bytes, but %d Error: %v", len(content)-3, n, err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), content[3:]) {
+		t.Fatalf("Expected % x for bytes, but % x", content[3:], buf.Bytes())
+	}
+
+	if stream.Len() != 0 {
+		t.Fatalf("Expected %d for len, but %d", 0, stream.Len())
+	}
+
+	// ReadFrom returns an error of the reader
+	_, err = NewStream().ReadFrom(iotest.ErrReader(io.ErrClosedPipe))
+	if err != io.ErrClosedPipe {
+		t.Fatalf("Expected %v, but %v", io.ErrClosedPipe, err)
+	}
+}
+
+func TestStreamGzip(t *testing.T) {
+	stream := NewStream()
+
+	zw := gzip.NewWriter(stream)
+	zw.Write(Magic)
+	zw.Close()
+
+	zr, err := gzip.NewReader(bufio.NewReader(stream))
+	if err != nil {
+		t.Fatalf("Failed to read gzip header Error: %s", err)
+	}
+
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Failed to read gzip Error: %s", err)
+	}
+
+	if !bytes.Equal(b, Magic) {
+		t.Fatalf("Expected % x for bytes, but % x", Magic, b)
+	}
+}
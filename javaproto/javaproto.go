@@ -0,0 +1,338 @@
+// Package javaproto implements packet framing of Minecraft Java Edition protocol
+//
+// A frame is the length (VarInt) and the data.
+// If compression is enabled, the data is the length of uncompressed data (VarInt, 0 if it's not compressed)
+// and the zlib compressed data.
+// Packets are the packet ID (VarInt) and the fields.
+//
+// VarInts of Java Edition aren't ZigZag encoded.
+// Use UVarInt of Stream, and convert the value to int32 for signed values.
+package javaproto
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+
+	"github.com/beito123/binary"
+)
+
+// MaxPacketSize is the default max size of frames and uncompressed packets
+// It's max value of a 3 bytes VarInt, which is used by the vanilla server.
+const MaxPacketSize = 1<<21 - 1
+
+var (
+	// ErrTooLarge is returned when a packet is larger than the max size
+	ErrTooLarge = errors.New("javaproto: packet is too large")
+
+	// ErrEmptyPacket is returned when a frame doesn't have a packet ID
+	ErrEmptyPacket = errors.New("javaproto: empty packet")
+
+	// ErrBadCompression is returned when a compressed packet is invalid
+	ErrBadCompression = errors.New("javaproto: badly compressed packet")
+
+	// ErrInvalidLevel is returned when a compression level is invalid
+	ErrInvalidLevel = errors.New("javaproto: invalid compression level")
+)
+
+// Packet is a packet with the ID
+// Stream has the fields after the ID.
+type Packet struct {
+	ID int32
+	*binary.Stream
+}
+
+// NewPacket returns new Packet with the ID and an empty Stream
+func NewPacket(id int32) *Packet {
+	return &Packet{
+		ID:     id,
+		Stream: binary.NewStream(),
+	}
+}
+
+// NewReader returns new Reader reading frames from r
+// Compression is disabled until SetThreshold is called.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r:         r,
+		threshold: -1,
+		MaxSize:   MaxPacketSize,
+	}
+}
+
+// Reader reads packets from an io.Reader
+//
+// Reader doesn't read ahead of packets, so the io.Reader can be replaced with SetReader between packets.
+// It's used to start decryption after Encryption Response.
+type Reader struct {
+	r         io.Reader
+	threshold int
+	zr        io.ReadCloser
+	one       [1]byte
+
+	// MaxSize is max size of frames and uncompressed packets
+	MaxSize int
+}
+
+// SetReader replaces the io.Reader reading next packets
+func (r *Reader) SetReader(rd io.Reader) {
+	r.r = rd
+}
+
+// SetThreshold sets the compression threshold
+// If n is negative, compression is disabled.
+func (r *Reader) SetThreshold(n int) {
+	r.threshold = n
+}
+
+// Threshold returns the compression threshold, or a negative value if compression is disabled
+func (r *Reader) Threshold() int {
+	return r.threshold
+}
+
+// ReadPacket reads a packet
+func (r *Reader) ReadPacket() (*Packet, error) {
+	ln, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(ln) > uint64(r.MaxSize) {
+		return nil, ErrTooLarge
+	}
+
+	frame := make([]byte, ln)
+	if _, err := io.ReadFull(r.r, frame); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	data := frame
+	if r.threshold >= 0 {
+		data, err = r.decompress(frame)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, ErrEmptyPacket
+	}
+
+	id, n, err := binary.ReadEUVarInt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Packet{
+		ID:     int32(id),
+		Stream: binary.NewStreamBytes(data[n:]),
+	}, nil
+}
+
+// readByte reads a byte without reading ahead
+func (r *Reader) readByte() (byte, error) {
+	if br, ok := r.r.(io.ByteReader); ok {
+		return br.ReadByte()
+	}
+
+	_, err := io.ReadFull(r.r, r.one[:])
+
+	return r.one[0], err
+}
+
+// readLength reads the length of a frame
+func (r *Reader) readLength() (uint32, error) {
+	var b [binary.MaxVarIntSize]byte
+	for i := range b {
+		c, err := r.readByte()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return 0, err
+		}
+
+		b[i] = c
+		if c&0x80 == 0 {
+			v, _, err := binary.ReadEUVarInt(b[:i+1])
+
+			return v, err
+		}
+	}
+
+	return 0, binary.ErrVarIntOverflow
+}
+
+// decompress returns uncompressed data of the frame
+func (r *Reader) decompress(frame []byte) ([]byte, error) {
+	ln, n, err := binary.ReadEUVarInt(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	if ln == 0 { // not compressed
+		return frame[n:], nil
+	}
+
+	if int64(ln) < int64(r.threshold) {
+		return nil, ErrBadCompression
+	}
+
+	if uint64(ln) > uint64(r.MaxSize) {
+		return nil, ErrTooLarge
+	}
+
+	src := bytes.NewReader(frame[n:])
+	if r.zr == nil {
+		r.zr, err = zlib.NewReader(src)
+	} else {
+		err = r.zr.(zlib.Resetter).Reset(src, nil)
+	}
+
+	if err != nil {
+		return nil, ErrBadCompression
+	}
+
+	data := make([]byte, ln)
+	if _, err := io.ReadFull(r.zr, data); err != nil {
+		return nil, ErrBadCompression
+	}
+
+	// the data must end at the length, and the checksum is verified at the end
+	if _, err := io.ReadFull(r.zr, r.one[:]); err != io.EOF {
+		return nil, ErrBadCompression
+	}
+
+	return data, nil
+}
+
+// NewWriter returns new Writer writing frames to w
+// Compression is disabled until SetThreshold is called.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w:         w,
+		threshold: -1,
+		level:     zlib.DefaultCompression,
+		MaxSize:   MaxPacketSize,
+	}
+}
+
+// Writer writes packets to an io.Writer
+type Writer struct {
+	w         io.Writer
+	threshold int
+	level     int
+	zw        *zlib.Writer
+	buf       bytes.Buffer
+
+	// MaxSize is max size of frames and uncompressed packets
+	MaxSize int
+}
+
+// SetWriter replaces the io.Writer writing next packets
+// It's used to start encryption after Encryption Response.
+func (w *Writer) SetWriter(wr io.Writer) {
+	w.w = wr
+}
+
+// SetThreshold sets the compression threshold
+// Packets of the threshold or larger are compressed. If n is negative, compression is disabled.
+func (w *Writer) SetThreshold(n int) {
+	w.threshold = n
+}
+
+// Threshold returns the compression threshold, or a negative value if compression is disabled
+func (w *Writer) Threshold() int {
+	return w.threshold
+}
+
+// SetLevel sets the zlib compression level
+func (w *Writer) SetLevel(level int) error {
+	if level < zlib.HuffmanOnly || level > zlib.BestCompression {
+		return ErrInvalidLevel
+	}
+
+	w.level = level
+	w.zw = nil
+
+	return nil
+}
+
+// WritePacket writes the packet
+// It writes all bytes of the Stream of the packet as the fields.
+func (w *Writer) WritePacket(p *Packet) error {
+	data := binary.AppendUVarInt(nil, uint32(p.ID))
+	data = append(data, p.AllBytes()...)
+
+	if len(data) > w.MaxSize {
+		return ErrTooLarge
+	}
+
+	var frame []byte
+	switch {
+	case w.threshold < 0:
+		frame = data
+	case len(data) < w.threshold:
+		frame = append([]byte{0x00}, data...)
+	default:
+		compressed, err := w.compress(data)
+		if err != nil {
+			return err
+		}
+
+		frame = binary.AppendUVarInt(nil, uint32(len(data)))
+		frame = append(frame, compressed...)
+	}
+
+	if len(frame) > w.MaxSize {
+		return ErrTooLarge
+	}
+
+	b := binary.AppendUVarInt(make([]byte, 0, binary.MaxVarIntSize+len(frame)), uint32(len(frame)))
+	b = append(b, frame...)
+
+	_, err := w.w.Write(b)
+
+	return err
+}
+
+// compress returns zlib compressed data
+func (w *Writer) compress(data []byte) ([]byte, error) {
+	w.buf.Reset()
+
+	if w.zw == nil {
+		zw, err := zlib.NewWriterLevel(&w.buf, w.level)
+		if err != nil {
+			return nil, err
+		}
+
+		w.zw = zw
+	} else {
+		w.zw.Reset(&w.buf)
+	}
+
+	if _, err := w.zw.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return w.buf.Bytes(), nil
+}
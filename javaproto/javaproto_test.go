@@ -0,0 +1,261 @@
+package javaproto
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"crypto/aes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/beito123/binary"
+	"github.com/beito123/binary/cfb8"
+)
+
+// handshake is a Handshake packet (protocol 754, localhost:25565, next state 1)
+var handshake = []byte{
+	0x10, 0x00, 0xf2, 0x05, 0x09,
+	'l', 'o', 'c', 'a', 'l', 'h', 'o', 's', 't',
+	0x63, 0xdd, 0x01,
+}
+
+func newHandshake(t *testing.T) *Packet {
+	p := NewPacket(0x00)
+	p.PutUVarInt(754)
+
+	err := p.PutString(binary.PrefixVarInt, "localhost")
+	if err != nil {
+		t.Fatalf("Failed to put a string Error: %s", err)
+	}
+
+	p.PutShort(25565)
+	p.PutUVarInt(1)
+
+	return p
+}
+
+func TestWritePacket(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	err := NewWriter(buf).WritePacket(newHandshake(t))
+	if err != nil {
+		t.Fatalf("Failed to write a packet Error: %s", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), handshake) {
+		t.Errorf("Expected %x, but %x", handshake, buf.Bytes())
+	}
+}
+
+func TestReadPacket(t *testing.T) {
+	r := NewReader(bytes.NewReader(handshake))
+
+	p, err := r.ReadPacket()
+	if err != nil {
+		t.Fatalf("Failed to read a packet Error: %s", err)
+	}
+
+	if p.ID != 0x00 {
+		t.Errorf("Expected %d for ID, but %d", 0x00, p.ID)
+	}
+
+	protocol, _ := p.UVarInt()
+	if protocol != 754 {
+		t.Errorf("Expected %d for protocol, but %d", 754, protocol)
+	}
+
+	host, _ := p.String(binary.PrefixVarInt)
+	if host != "localhost" {
+		t.Errorf("Expected %s for host, but %s", "localhost", host)
+	}
+
+	port, _ := p.Short()
+	if port != 25565 {
+		t.Errorf("Expected %d for port, but %d", 25565, port)
+	}
+
+	state, err := p.UVarInt()
+	if err != nil || state != 1 {
+		t.Errorf("Expected %d for state, but %d (%v)", 1, state, err)
+	}
+
+	_, err = r.ReadPacket()
+	if err != io.EOF {
+		t.Errorf("Expected io.EOF, but %v", err)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	w := NewWriter(buf)
+	w.SetThreshold(256)
+
+	small := NewPacket(0x01)
+	small.Put([]byte{0xaa, 0xbb})
+
+	large := NewPacket(0x7f)
+	large.Put(bytes.Repeat([]byte("binary"), 1000))
+
+	for _, p := range []*Packet{small, large} {
+		if err := w.WritePacket(p); err != nil {
+			t.Fatalf("Failed to write a packet Error: %s", err)
+		}
+	}
+
+	// a packet under the threshold has 0 as the data length
+	want := []byte{0x04, 0x00, 0x01, 0xaa, 0xbb}
+	if !bytes.HasPrefix(buf.Bytes(), want) {
+		t.Errorf("Expected %x, but %x", want, buf.Bytes()[:len(want)])
+	}
+
+	if buf.Len() >= len(want)+large.Len() {
+		t.Errorf("Expected the large packet is compressed, but %d bytes", buf.Len()-len(want))
+	}
+
+	r := NewReader(buf)
+	r.SetThreshold(256)
+
+	for _, want := range []*Packet{small, large} {
+		p, err := r.ReadPacket()
+		if err != nil {
+			t.Fatalf("Failed to read a packet Error: %s", err)
+		}
+
+		if p.ID != want.ID {
+			t.Errorf("Expected %d for ID, but %d", want.ID, p.ID)
+		}
+
+		if !bytes.Equal(p.AllBytes(), want.AllBytes()) {
+			t.Errorf("Expected %d bytes for packet 0x%x, but %d bytes", want.Len(), want.ID, p.Len())
+		}
+	}
+}
+
+func TestReadPacketError(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		threshold int
+		err       error
+	}{
+		{"too large", []byte{0x80, 0x80, 0x80, 0x01}, -1, ErrTooLarge},
+		{"empty", []byte{0x00}, -1, ErrEmptyPacket},
+		{"short frame", []byte{0x05, 0x00, 0x01}, -1, io.ErrUnexpectedEOF},
+		{"under threshold", []byte{0x03, 0x02, 0x78, 0x9c}, 256, ErrBadCompression},
+		{"too large data", []byte{0x05, 0x80, 0x80, 0x80, 0x01, 0x00}, 0, ErrTooLarge},
+		{"broken zlib", []byte{0x04, 0x02, 0x00, 0x00, 0x00}, 0, ErrBadCompression},
+	}
+
+	for _, test := range tests {
+		r := NewReader(bytes.NewReader(test.data))
+		r.SetThreshold(test.threshold)
+
+		_, err := r.ReadPacket()
+		if !errors.Is(err, test.err) {
+			t.Errorf("Expected %v for %s, but %v", test.err, test.name, err)
+		}
+	}
+}
+
+func TestWritePacketTooLarge(t *testing.T) {
+	p := NewPacket(0x00)
+	p.Put(make([]byte, MaxPacketSize))
+
+	err := NewWriter(io.Discard).WritePacket(p)
+	if err != ErrTooLarge {
+		t.Errorf("Expected %v, but %v", ErrTooLarge, err)
+	}
+}
+
+func TestReadPacketChecksum(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	w := NewWriter(buf)
+	w.SetThreshold(0)
+
+	p := NewPacket(0x01)
+	p.Put(bytes.Repeat([]byte{0xaa}, 100))
+	w.WritePacket(p)
+
+	// breaks the Adler-32 checksum at the end
+	data := buf.Bytes()
+	data[len(data)-1] ^= 0xff
+
+	r := NewReader(bytes.NewReader(data))
+	r.SetThreshold(0)
+
+	_, err := r.ReadPacket()
+	if err != ErrBadCompression {
+		t.Errorf("Expected %v, but %v", ErrBadCompression, err)
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	secret := []byte("0123456789abcdef") // the shared secret is the key and the iv
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		t.Fatalf("Failed to create a cipher Error: %s", err)
+	}
+
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+
+	response := NewPacket(0x01) // Encryption Response
+	response.Put([]byte{0x01, 0x02})
+
+	packets := []*Packet{NewPacket(0x02), NewPacket(0x03)}
+	packets[0].Put([]byte("encrypted"))
+	packets[1].PutShort(0x1234)
+
+	if err := w.WritePacket(response); err != nil {
+		t.Fatalf("Failed to write a packet Error: %s", err)
+	}
+
+	w.SetWriter(cfb8.NewWriter(buf, block, secret))
+
+	for _, p := range packets {
+		if err := w.WritePacket(p); err != nil {
+			t.Fatalf("Failed to write a packet Error: %s", err)
+		}
+	}
+
+	if bytes.Contains(buf.Bytes(), []byte("encrypted")) {
+		t.Fatalf("Expected the packets are encrypted, but not")
+	}
+
+	// a reader without io.ByteReader
+	conn := iotest.HalfReader(bytes.NewReader(buf.Bytes()))
+	r := NewReader(conn)
+
+	p, err := r.ReadPacket()
+	if err != nil || p.ID != response.ID {
+		t.Fatalf("Expected %d for ID, but %v Error: %v", response.ID, p, err)
+	}
+
+	r.SetReader(cfb8.NewReader(conn, block, secret))
+
+	for _, want := range packets {
+		p, err := r.ReadPacket()
+		if err != nil {
+			t.Fatalf("Failed to read a packet Error: %s", err)
+		}
+
+		if p.ID != want.ID || !bytes.Equal(p.AllBytes(), want.AllBytes()) {
+			t.Errorf("Expected % x for packet 0x%x, but % x for 0x%x", want.AllBytes(), want.ID, p.AllBytes(), p.ID)
+		}
+	}
+
+	if _, err := r.ReadPacket(); err != io.EOF {
+		t.Errorf("Expected io.EOF, but %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"errors"
+)
+
+// ErrNoLength is returned when EndLength is called without BeginLength
+var ErrNoLength = errors.New("binary: EndLength without BeginLength")
+
+// lengthMark is a placeholder of a length put by BeginLength
+type lengthMark struct {
+	prefix Prefix
+	off    int // offset of the placeholder in buf
+	size   int // size of the placeholder
+}
+
+// endedMark is a mark ended by EndLength
+type endedMark struct {
+	lengthMark
+	depth int // index of the mark in lengths
+	shift int // bytes which the body is moved by
+}
+
+// BeginLength puts a placeholder of a length with the prefix
+// The length of bytes put until EndLength is filled in the placeholder.
+// BeginLength and EndLength can be nested.
+func (bs *Stream) BeginLength(prefix Prefix) error {
+	off := len(bs.buf)
+
+	b, err := AppendLength(bs.buf, prefix, 0)
+	if err != nil {
+		return err
+	}
+
+	bs.buf = b
+	bs.lengths = append(bs.lengths, lengthMark{
+		prefix: prefix,
+		off:    off,
+		size:   len(b) - off,
+	})
+
+	return nil
+}
+
+// EndLength fills in the placeholder of the last BeginLength with the length of bytes put after it
+// For PrefixVarInt, the bytes are moved if the length needs more than a byte.
+func (bs *Stream) EndLength() error {
+	if len(bs.lengths) == 0 {
+		return ErrNoLength
+	}
+
+	n := len(bs.lengths) - 1
+	m := bs.lengths[n]
+
+	start := m.off + m.size
+	ln := len(bs.buf) - start
+
+	var tmp [MaxVarIntSize]byte
+	b, err := AppendLength(tmp[:0], m.prefix, ln)
+	if err != nil {
+		return err
+	}
+
+	shift := len(b) - m.size
+	if shift > 0 {
+		bs.buf = append(bs.buf, make([]byte, shift)...)
+		copy(bs.buf[start+shift:], bs.buf[start:start+ln])
+	}
+
+	copy(bs.buf[m.off:], b)
+
+	bs.lengths = bs.lengths[:n]
+	bs.ended = append(bs.ended, endedMark{lengthMark: m, depth: n, shift: shift})
+
+	return nil
+}
+
+// unendLength undoes EndLength of the mark
+// It moves the body back and puts the placeholder again.
+func (bs *Stream) unendLength(e endedMark) {
+	start := e.off + e.size
+	if e.shift > 0 {
+		copy(bs.buf[start:], bs.buf[start+e.shift:])
+	}
+
+	AppendLength(bs.buf[:e.off], e.prefix, 0)
+
+	bs.lengths = append(bs.lengths[:e.depth], e.lengthMark)
+}
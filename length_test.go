@@ -0,0 +1,123 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStreamLength(t *testing.T) {
+	stream := NewStream()
+
+	stream.BeginLength(PrefixShort)
+	stream.PutByte(0x01)
+	stream.BeginLength(PrefixLInt)
+	stream.PutShort(0x0203)
+	stream.EndLength()
+	stream.EndLength()
+
+	exp := []byte{0x00, 0x07, 0x01, 0x02, 0x00, 0x00, 0x00, 0x02, 0x03}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+}
+
+func TestStreamLengthVarInt(t *testing.T) {
+	body := bytes.Repeat([]byte{0xaa}, 300)
+
+	stream := NewStream()
+	stream.BeginLength(PrefixVarInt)
+	stream.BeginLength(PrefixVarInt)
+	stream.Put(body)
+	stream.EndLength()
+	stream.PutByte(0xbb)
+	stream.BeginLength(PrefixVarInt)
+	stream.EndLength()
+	stream.EndLength()
+
+	exp := NewStream()
+	exp.PutUVarInt(2 + 300 + 2)
+	exp.PutUVarInt(300)
+	exp.Put(body)
+	exp.PutByte(0xbb)
+	exp.PutUVarInt(0)
+
+	if !bytes.Equal(stream.AllBytes(), exp.AllBytes()) {
+		t.Fatalf("Expected % x for bytes, but % x", exp.AllBytes(), stream.AllBytes())
+	}
+
+	b, err := stream.ByteArray(PrefixVarInt)
+	if err != nil {
+		t.Fatalf("Failed to get bytes Error: %s", err)
+	}
+
+	if len(b) != 304 {
+		t.Fatalf("Expected %d for len, but %d", 304, len(b))
+	}
+}
+
+func TestStreamLengthError(t *testing.T) {
+	stream := NewStream()
+
+	err := stream.EndLength()
+	if !errors.Is(err, ErrNoLength) {
+		t.Fatalf("Expected %v, but %v", ErrNoLength, err)
+	}
+
+	stream.BeginLength(PrefixByte)
+	stream.Pad(256)
+
+	err = stream.EndLength()
+	if !errors.Is(err, ErrPrefixOverflow) {
+		t.Fatalf("Expected %v, but %v", ErrPrefixOverflow, err)
+	}
+
+	// the failed EndLength keeps the placeholder
+	stream.Reset()
+	stream.BeginLength(PrefixByte)
+	cp := stream.Mark()
+	stream.Pad(256)
+
+	err = stream.EndLength()
+	if !errors.Is(err, ErrPrefixOverflow) {
+		t.Fatalf("Expected %v, but %v", ErrPrefixOverflow, err)
+	}
+
+	stream.Rewind(cp)
+	stream.Pad(2)
+
+	err = stream.EndLength()
+	if err != nil {
+		t.Fatalf("Failed to end length Error: %s", err)
+	}
+
+	exp := []byte{0x02, 0x00, 0x00}
+	if !bytes.Equal(stream.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, stream.AllBytes())
+	}
+
+	err = stream.BeginLength(Prefix(-1))
+	if !errors.Is(err, ErrUnknownPrefix) {
+		t.Fatalf("Expected %v, but %v", ErrUnknownPrefix, err)
+	}
+
+	// Rewind discards placeholders after the checkpoint
+	stream.Reset()
+	cp = stream.Mark()
+	stream.BeginLength(PrefixInt)
+	stream.Rewind(cp)
+
+	err = stream.EndLength()
+	if !errors.Is(err, ErrNoLength) {
+		t.Fatalf("Expected %v, but %v", ErrNoLength, err)
+	}
+}
@@ -0,0 +1,741 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TagName is the key of struct tags used by Marshal and Unmarshal
+const TagName = "binary"
+
+var (
+	// ErrInvalidValue is returned when a nil value is passed to Marshal, or a non-pointer to Unmarshal
+	ErrInvalidValue = errors.New("binary: invalid value (nil or not a pointer)")
+
+	// ErrLenMismatch is returned when the length of a slice doesn't match the field of len option
+	ErrLenMismatch = errors.New("binary: length doesn't match the len field")
+
+	// ErrNegativeLen is returned when the field of len option is negative
+	ErrNegativeLen = errors.New("binary: len field is negative")
+)
+
+// TypeError is returned when a value of unsupported type is passed
+type TypeError struct {
+	Type reflect.Type
+}
+
+func (e *TypeError) Error() string {
+	return "binary: unsupported type " + e.Type.String()
+}
+
+// FieldError is returned when a field of a struct can't be encoded or decoded
+type FieldError struct {
+	Struct string
+	Field  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return "binary: field " + e.Struct + "." + e.Field + ": " + strings.TrimPrefix(e.Err.Error(), "binary: ")
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// TagError is returned when a struct tag is invalid
+type TagError struct {
+	Tag string
+	Msg string
+}
+
+func (e *TagError) Error() string {
+	return "binary: invalid tag \"" + e.Tag + "\": " + e.Msg
+}
+
+// Tag is options of a struct field
+//
+// The options are written in the struct tag with comma separated
+//
+//	"-"            ignores the field
+//	big, little    encodes the field with the byte order
+//	varint         encodes an int32, int64, uint32 or uint64 as varint (signed with ZigZag)
+//	triad          encodes an int32 or uint32 as triad
+//	prefix=kind    length prefix of strings and slices (byte, short, lshort, int, lint, varint)
+//	len=Field      takes the length of a slice from the sibling field instead of a prefix (only slices)
+//	cstring        encodes a string as null terminated
+//	fixed=n        encodes a string as n bytes padded with 0x00
+//	skip=n         skips n bytes (0x00) before the field
+//
+// e.g. `binary:"little,prefix=varint"`
+type Tag struct {
+	Ignore  bool
+	Order   Order
+	VarInt  bool
+	Triad   bool
+	Prefix  Prefix
+	Len     string
+	CString bool
+	Fixed   int
+	Skip    int
+}
+
+// prefixNames is names of prefixes used in struct tags
+var prefixNames = map[string]Prefix{
+	"byte":   PrefixByte,
+	"short":  PrefixShort,
+	"lshort": PrefixLShort,
+	"int":    PrefixInt,
+	"lint":   PrefixLInt,
+	"varint": PrefixVarInt,
+}
+
+// ParseTag parses a struct tag value
+// Strings and slices use PrefixVarInt unless prefix or len is specified
+func ParseTag(tag string) (Tag, error) {
+	opt := Tag{
+		Prefix: PrefixVarInt,
+	}
+
+	if tag == "" {
+		return opt, nil
+	}
+
+	if tag == "-" {
+		opt.Ignore = true
+
+		return opt, nil
+	}
+
+	for _, s := range strings.Split(tag, ",") {
+		key, value := s, ""
+		if i := strings.IndexByte(s, '='); i >= 0 {
+			key, value = s[:i], s[i+1:]
+		}
+
+		var err error
+		switch key {
+		case "big":
+			opt.Order = BigEndian
+		case "little":
+			opt.Order = LittleEndian
+		case "varint":
+			opt.VarInt = true
+		case "triad":
+			opt.Triad = true
+		case "cstring":
+			opt.CString = true
+		case "prefix":
+			prefix, ok := prefixNames[value]
+			if !ok {
+				return opt, &TagError{Tag: tag, Msg: "unknown prefix " + value}
+			}
+
+			opt.Prefix = prefix
+		case "len":
+			if value == "" {
+				return opt, &TagError{Tag: tag, Msg: "len needs a field name"}
+			}
+
+			opt.Len = value
+		case "fixed":
+			opt.Fixed, err = strconv.Atoi(value)
+		case "skip":
+			opt.Skip, err = strconv.Atoi(value)
+		default:
+			return opt, &TagError{Tag: tag, Msg: "unknown option " + key}
+		}
+
+		if err != nil || opt.Fixed < 0 || opt.Skip < 0 {
+			return opt, &TagError{Tag: tag, Msg: "invalid number " + value}
+		}
+	}
+
+	if opt.VarInt && opt.Triad {
+		return opt, &TagError{Tag: tag, Msg: "varint and triad can't be used together"}
+	}
+
+	return opt, nil
+}
+
+// Marshal encodes v with the order
+// v is a struct, a slice, an array or a fixed size value, and struct fields are encoded by options of tags
+func Marshal(order Order, v interface{}) ([]byte, error) {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	if !val.IsValid() {
+		return nil, ErrInvalidValue
+	}
+
+	enc := &encoder{
+		Stream: NewStream(),
+	}
+
+	err := enc.value(val, order, Tag{Prefix: PrefixVarInt})
+	if err != nil {
+		return nil, err
+	}
+
+	return enc.AllBytes(), nil
+}
+
+// Unmarshal decodes data with the order and stores the result in the value pointed to by v
+func Unmarshal(data []byte, order Order, v interface{}) error {
+	return unmarshal(data, order, v, false)
+}
+
+// UnmarshalStrict is like Unmarshal, but returns an error if bytes are left after decoding
+func UnmarshalStrict(data []byte, order Order, v interface{}) error {
+	return unmarshal(data, order, v, true)
+}
+
+func unmarshal(data []byte, order Order, v interface{}, strict bool) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return ErrInvalidValue
+	}
+
+	dec := &decoder{
+		Stream: NewStreamBytes(data),
+	}
+
+	err := dec.value(val.Elem(), order, Tag{Prefix: PrefixVarInt})
+	if err != nil {
+		return err
+	}
+
+	if strict {
+		return dec.ExpectEnd()
+	}
+
+	return nil
+}
+
+// fieldTag parses the tag of a struct field and checks it can be used on the field
+func fieldTag(field reflect.StructField) (Tag, error) {
+	s := field.Tag.Get(TagName)
+
+	tag, err := ParseTag(s)
+	if err != nil {
+		return tag, err
+	}
+
+	if tag.Len != "" && field.Type.Kind() != reflect.Slice {
+		return tag, &TagError{Tag: s, Msg: "len can be used only on slices"}
+	}
+
+	return tag, nil
+}
+
+// prefixSize returns the minimum size of the prefix
+func prefixSize(prefix Prefix) int {
+	switch prefix {
+	case PrefixShort, PrefixLShort:
+		return ShortSize
+	case PrefixInt, PrefixLInt:
+		return IntSize
+	}
+
+	return ByteSize
+}
+
+// minSize returns the minimum count of bytes of a value of the type encoded with the tag
+// It's used to check lengths of slices before allocating them. It returns 0 for unsupported types.
+func minSize(typ reflect.Type, tag Tag) int {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return ByteSize
+	case reflect.Int16, reflect.Uint16:
+		return ShortSize
+	case reflect.Int32, reflect.Uint32:
+		if tag.VarInt {
+			return ByteSize
+		} else if tag.Triad {
+			return TriadSize
+		}
+
+		return IntSize
+	case reflect.Int64, reflect.Uint64:
+		if tag.VarInt {
+			return ByteSize
+		}
+
+		return LongSize
+	case reflect.Float32:
+		return FloatSize
+	case reflect.Float64:
+		return DoubleSize
+	case reflect.String:
+		if tag.CString {
+			return ByteSize
+		} else if tag.Fixed > 0 {
+			return tag.Fixed
+		}
+
+		return prefixSize(tag.Prefix)
+	case reflect.Slice:
+		if tag.Len != "" { // the length may be 0
+			return 0
+		}
+
+		return prefixSize(tag.Prefix)
+	case reflect.Array:
+		tag.Len = ""
+
+		return typ.Len() * minSize(typ.Elem(), tag)
+	case reflect.Struct:
+		size := 0
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" { // unexported
+				continue
+			}
+
+			ftag, err := fieldTag(field)
+			if err != nil || ftag.Ignore {
+				continue
+			}
+
+			size += ftag.Skip + minSize(field.Type, ftag)
+		}
+
+		return size
+	}
+
+	return 0
+}
+
+// checkLen returns an error if the buffer doesn't have ln elements of the slice type
+// It's checked before allocating the slice.
+func (dec *decoder) checkLen(typ reflect.Type, ln int, tag Tag) error {
+	tag.Len = "" // elements don't have len
+	size := minSize(typ.Elem(), tag)
+	if size > 0 && ln > dec.Len()/size {
+		return dec.fail(typ.String(), ln*size, ErrNotEnought)
+	}
+
+	return nil
+}
+
+// siblingLen returns the value of an integer field as length
+func siblingLen(v reflect.Value, name string) (int, error) {
+	field := v.FieldByName(name)
+	if !field.IsValid() {
+		return 0, errors.New("binary: no field " + name + " for len")
+	}
+
+	var ln int
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ln = int(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		ln = int(field.Uint())
+	default:
+		return 0, errors.New("binary: field " + name + " for len isn't an integer")
+	}
+
+	return ln, nil
+}
+
+type encoder struct {
+	*Stream
+}
+
+func (enc *encoder) value(v reflect.Value, order Order, tag Tag) error {
+	if tag.Order != nil {
+		order = tag.Order
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return enc.PutBool(v.Bool())
+	case reflect.Int8:
+		return enc.PutSByte(int8(v.Int()))
+	case reflect.Uint8:
+		return enc.PutByte(uint8(v.Uint()))
+	case reflect.Int16:
+		return enc.Put(order.PutShort(int16(v.Int())))
+	case reflect.Uint16:
+		return enc.Put(order.PutUShort(uint16(v.Uint())))
+	case reflect.Int32:
+		if tag.VarInt {
+			return enc.PutVarInt(int32(v.Int()))
+		} else if tag.Triad {
+			return enc.Put(order.PutTriad(int32(v.Int())))
+		}
+
+		return enc.Put(order.PutInt(int32(v.Int())))
+	case reflect.Uint32:
+		if tag.VarInt {
+			return enc.PutUVarInt(uint32(v.Uint()))
+		} else if tag.Triad {
+			return enc.Put(order.PutUTriad(uint32(v.Uint())))
+		}
+
+		return enc.Put(order.PutUInt(uint32(v.Uint())))
+	case reflect.Int64:
+		if tag.VarInt {
+			return enc.PutVarLong(v.Int())
+		}
+
+		return enc.Put(order.PutLong(v.Int()))
+	case reflect.Uint64:
+		if tag.VarInt {
+			return enc.PutUVarLong(v.Uint())
+		}
+
+		return enc.Put(order.PutULong(v.Uint()))
+	case reflect.Float32:
+		return enc.Put(order.PutFloat(float32(v.Float())))
+	case reflect.Float64:
+		return enc.Put(order.PutDouble(v.Float()))
+	case reflect.String:
+		if tag.CString {
+			return enc.PutCString(v.String())
+		} else if tag.Fixed > 0 {
+			return enc.PutFixedString(tag.Fixed, v.String())
+		}
+
+		return enc.PutString(tag.Prefix, v.String())
+	case reflect.Array:
+		return enc.elements(v, order, tag)
+	case reflect.Slice:
+		if tag.Len == "" {
+			err := enc.PutLength(tag.Prefix, v.Len())
+			if err != nil {
+				return err
+			}
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return enc.Put(v.Bytes())
+		}
+
+		return enc.elements(v, order, tag)
+	case reflect.Struct:
+		return enc.fields(v, order)
+	}
+
+	return &TypeError{Type: v.Type()}
+}
+
+func (enc *encoder) elements(v reflect.Value, order Order, tag Tag) error {
+	tag.Len = ""
+	for i := 0; i < v.Len(); i++ {
+		err := enc.value(v.Index(i), order, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (enc *encoder) fields(v reflect.Value, order Order) error {
+	typ := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		tag, err := fieldTag(field)
+		if err != nil {
+			return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+		}
+
+		if tag.Ignore {
+			continue
+		}
+
+		if tag.Skip > 0 {
+			err = enc.Pad(tag.Skip)
+			if err != nil {
+				return err
+			}
+		}
+
+		if tag.Len != "" {
+			ln, err := siblingLen(v, tag.Len)
+			if err != nil {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+			}
+
+			if ln != v.Field(i).Len() {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: ErrLenMismatch}
+			}
+		}
+
+		err = enc.value(v.Field(i), order, tag)
+		if err != nil {
+			if _, ok := err.(*FieldError); ok {
+				return err
+			}
+
+			return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+type decoder struct {
+	*Stream
+}
+
+func (dec *decoder) value(v reflect.Value, order Order, tag Tag) error {
+	if tag.Order != nil {
+		order = tag.Order
+	}
+
+	var size int
+	switch v.Kind() {
+	case reflect.Bool:
+		val, err := dec.Bool()
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(val)
+
+		return nil
+	case reflect.Int8:
+		val, err := dec.SByte()
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(val))
+
+		return nil
+	case reflect.Uint8:
+		val, err := dec.Byte()
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(uint64(val))
+
+		return nil
+	case reflect.Int32, reflect.Uint32:
+		if tag.VarInt {
+			return dec.varInt(v)
+		}
+
+		size = IntSize
+		if tag.Triad {
+			size = TriadSize
+		}
+	case reflect.Int64, reflect.Uint64:
+		if tag.VarInt {
+			return dec.varInt(v)
+		}
+
+		size = LongSize
+	case reflect.Int16, reflect.Uint16:
+		size = ShortSize
+	case reflect.Float32:
+		size = FloatSize
+	case reflect.Float64:
+		size = DoubleSize
+	case reflect.String:
+		var val string
+		var err error
+		if tag.CString {
+			val, err = dec.CString()
+		} else if tag.Fixed > 0 {
+			val, err = dec.FixedString(tag.Fixed)
+		} else {
+			val, err = dec.String(tag.Prefix)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.SetString(val)
+
+		return nil
+	case reflect.Array:
+		return dec.elements(v, order, tag)
+	case reflect.Slice:
+		ln := v.Len() // set by the len option
+		if tag.Len == "" {
+			var err error
+			ln, err = dec.Length(tag.Prefix)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := dec.checkLen(v.Type(), ln, tag); err != nil {
+			return err
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, ln)
+			copy(b, dec.Get(ln))
+			v.SetBytes(b)
+
+			return nil
+		}
+
+		v.Set(reflect.MakeSlice(v.Type(), ln, ln))
+
+		return dec.elements(v, order, tag)
+	case reflect.Struct:
+		return dec.fields(v, order)
+	default:
+		return &TypeError{Type: v.Type()}
+	}
+
+	b, err := dec.get(v.Type().String(), size)
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Int16:
+		v.SetInt(int64(order.Short(b)))
+	case reflect.Uint16:
+		v.SetUint(uint64(order.UShort(b)))
+	case reflect.Int32:
+		if tag.Triad {
+			v.SetInt(int64(order.Triad(b)))
+		} else {
+			v.SetInt(int64(order.Int(b)))
+		}
+	case reflect.Uint32:
+		if tag.Triad {
+			v.SetUint(uint64(order.UTriad(b)))
+		} else {
+			v.SetUint(uint64(order.UInt(b)))
+		}
+	case reflect.Int64:
+		v.SetInt(order.Long(b))
+	case reflect.Uint64:
+		v.SetUint(order.ULong(b))
+	case reflect.Float32:
+		v.SetFloat(float64(order.Float(b)))
+	case reflect.Float64:
+		v.SetFloat(order.Double(b))
+	}
+
+	return nil
+}
+
+func (dec *decoder) varInt(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Int32:
+		val, err := dec.VarInt()
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(val))
+	case reflect.Uint32:
+		val, err := dec.UVarInt()
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(uint64(val))
+	case reflect.Int64:
+		val, err := dec.VarLong()
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(val)
+	case reflect.Uint64:
+		val, err := dec.UVarLong()
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(val)
+	}
+
+	return nil
+}
+
+func (dec *decoder) elements(v reflect.Value, order Order, tag Tag) error {
+	tag.Len = ""
+	for i := 0; i < v.Len(); i++ {
+		err := dec.value(v.Index(i), order, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dec *decoder) fields(v reflect.Value, order Order) error {
+	typ := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		tag, err := fieldTag(field)
+		if err != nil {
+			return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+		}
+
+		if tag.Ignore {
+			continue
+		}
+
+		if tag.Skip > 0 {
+			_, err = dec.get("skip", tag.Skip)
+			if err != nil {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+			}
+		}
+
+		if tag.Len != "" {
+			ln, err := siblingLen(v, tag.Len)
+			if err != nil {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+			}
+
+			if ln < 0 {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: dec.fail(field.Type.String(), 0, ErrNegativeLen)}
+			}
+
+			if dec.maxLen > 0 && ln > dec.maxLen {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: dec.fail(field.Type.String(), ln, ErrTooLong)}
+			}
+
+			if err := dec.checkLen(field.Type, ln, tag); err != nil {
+				return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+			}
+
+			v.Field(i).Set(reflect.MakeSlice(field.Type, ln, ln))
+		}
+
+		err = dec.value(v.Field(i), order, tag)
+		if err != nil {
+			if _, ok := err.(*FieldError); ok {
+				return err
+			}
+
+			return &FieldError{Struct: typ.Name(), Field: field.Name, Err: err}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testPosition struct {
+	X float32
+	Y float32 `binary:"big"`
+}
+
+type testPacket struct {
+	ID        byte
+	Sequence  uint32 `binary:"triad"`
+	Entity    int64  `binary:"varint"`
+	Name      string `binary:"prefix=short"`
+	Flags     uint16 `binary:"skip=2"`
+	Position  testPosition
+	Count     byte
+	Items     []int16 `binary:"len=Count"`
+	Payload   []byte  `binary:"prefix=byte"`
+	Hash      [2]uint16
+	OK        bool
+	Ignored   int `binary:"-"`
+	unexposed int
+}
+
+var testPacketData = []byte{
+	0x01,             // ID
+	0x03, 0x02, 0x01, // Sequence
+	0x03,                      // Entity
+	0x00, 0x03, 'a', 'b', 'c', // Name
+	0x00, 0x00, // skip
+	0x34, 0x12, // Flags
+	0x00, 0x00, 0x80, 0x3f, // Position.X
+	0x40, 0x00, 0x00, 0x00, // Position.Y
+	0x02,                   // Count
+	0xff, 0xff, 0x02, 0x00, // Items
+	0x01, 0xaa, // Payload
+	0x01, 0x00, 0x02, 0x00, // Hash
+	0x01, // OK
+}
+
+func TestMarshal(t *testing.T) {
+	pk := &testPacket{
+		ID:       1,
+		Sequence: 0x010203,
+		Entity:   -2,
+		Name:     "abc",
+		Flags:    0x1234,
+		Position: testPosition{X: 1, Y: 2},
+		Count:    2,
+		Items:    []int16{-1, 2},
+		Payload:  []byte{0xaa},
+		Hash:     [2]uint16{1, 2},
+		OK:       true,
+		Ignored:  10,
+	}
+
+	ret, err := Marshal(LittleEndian, pk)
+	if err != nil {
+		t.Fatalf("Failed to marshal Error: %s", err)
+	}
+
+	if !bytes.Equal(ret, testPacketData) {
+		t.Fatalf("Expected %d for bytes, but %d", testPacketData, ret)
+	}
+
+	pk.Count = 3
+	if _, err := Marshal(LittleEndian, pk); !errors.Is(err, ErrLenMismatch) {
+		t.Fatalf("Expected %v for mismatched len, but %v", ErrLenMismatch, err)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	exp := testPacket{
+		ID:       1,
+		Sequence: 0x010203,
+		Entity:   -2,
+		Name:     "abc",
+		Flags:    0x1234,
+		Position: testPosition{X: 1, Y: 2},
+		Count:    2,
+		Items:    []int16{-1, 2},
+		Payload:  []byte{0xaa},
+		Hash:     [2]uint16{1, 2},
+		OK:       true,
+	}
+
+	var pk testPacket
+	if err := Unmarshal(testPacketData, LittleEndian, &pk); err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(pk, exp) {
+		t.Fatalf("Expected %+v for packet, but %+v", exp, pk)
+	}
+
+	err := Unmarshal(testPacketData[:8], LittleEndian, &pk)
+
+	var ferr *FieldError
+	if !errors.As(err, &ferr) || ferr.Field != "Name" || !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected a field error for Name, but %v", err)
+	}
+
+	if err := Unmarshal(testPacketData, LittleEndian, pk); err != ErrInvalidValue {
+		t.Fatalf("Expected %v for non-pointer, but %v", ErrInvalidValue, err)
+	}
+}
+
+func TestMarshalFloat64(t *testing.T) {
+	type values struct {
+		L float64
+		B float64 `binary:"big"`
+	}
+
+	exp := values{L: 1.5, B: -2.25}
+	for _, order := range []Order{LittleEndian, BigEndian} {
+		b, err := Marshal(order, &exp)
+		if err != nil {
+			t.Fatalf("Failed to marshal Error: %s", err)
+		}
+
+		var v values
+		if err := Unmarshal(b, order, &v); err != nil {
+			t.Fatalf("Failed to unmarshal Error: %s", err)
+		}
+
+		if v != exp {
+			t.Fatalf("Expected %+v for values, but %+v", exp, v)
+		}
+	}
+
+	b, _ := Marshal(LittleEndian, &exp)
+	if ret := ReadLDouble(b[:DoubleSize]); ret != 1.5 {
+		t.Fatalf("Expected %f for little endian double, but %f", 1.5, ret)
+	}
+}
+
+func TestUnmarshalLenTooLarge(t *testing.T) {
+	data := append([]byte(nil), testPacketData[:23]...) // up to Count
+	data[22] = 0xff
+
+	var pk testPacket
+	err := Unmarshal(data, LittleEndian, &pk)
+
+	var ferr *FieldError
+	if !errors.As(err, &ferr) || ferr.Field != "Items" || !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected a field error for Items, but %v", err)
+	}
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || derr.Off != 23 || derr.Need != 0xff*ShortSize {
+		t.Fatalf("Expected a DecodeError at %d needing %d bytes, but %v", 23, 0xff*ShortSize, err)
+	}
+
+	if pk.Items != nil {
+		t.Fatalf("Expected no allocation for Items, but %d items", len(pk.Items))
+	}
+}
+
+func TestUnmarshalZeroSizeElements(t *testing.T) {
+	var v struct {
+		Count   byte
+		Empty   []struct{} `binary:"len=Count"`
+		Arrays  [][0]byte
+		Ignored []struct {
+			A int `binary:"-"`
+		} `binary:"prefix=byte"`
+	}
+
+	err := UnmarshalStrict([]byte{0x03, 0x02, 0x04}, LittleEndian, &v)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if len(v.Empty) != 3 || len(v.Arrays) != 2 || len(v.Ignored) != 4 {
+		t.Fatalf("Expected %d, %d and %d elements, but %d, %d and %d", 3, 2, 4, len(v.Empty), len(v.Arrays), len(v.Ignored))
+	}
+}
+
+func TestLenOnlySlices(t *testing.T) {
+	type lenString struct {
+		Count byte
+		Name  string `binary:"len=Count"`
+	}
+
+	type lenArray struct {
+		Count byte
+		Items [2]byte `binary:"len=Count"`
+	}
+
+	for _, v := range []interface{}{&lenString{}, &lenArray{Count: 2}} {
+		var terr *TagError
+
+		_, err := Marshal(LittleEndian, v)
+		if !errors.As(err, &terr) {
+			t.Fatalf("Expected a tag error for marshaling %T, but %v", v, err)
+		}
+
+		err = Unmarshal([]byte{0x02, 0x00, 0x00, 0x00}, LittleEndian, v)
+		if !errors.As(err, &terr) {
+			t.Fatalf("Expected a tag error for unmarshaling %T, but %v", v, err)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tag, err := ParseTag("little,varint,prefix=lint,skip=4")
+	if err != nil {
+		t.Fatalf("Failed to parse tag Error: %s", err)
+	}
+
+	exp := Tag{Order: LittleEndian, VarInt: true, Prefix: PrefixLInt, Skip: 4}
+	if tag != exp {
+		t.Fatalf("Expected %+v for tag, but %+v", exp, tag)
+	}
+
+	for _, s := range []string{"unknown", "prefix=long", "skip=a", "varint,triad"} {
+		if _, err := ParseTag(s); err == nil {
+			t.Fatalf("Expected an error for tag %s, but nil", s)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"github.com/beito123/binary"
+)
+
+// DefaultMaxDepth is the default max depth of compounds and lists
+const DefaultMaxDepth = 512
+
+// NewDecoder returns new Decoder reading the stream with the encoding
+func NewDecoder(s *binary.Stream, enc Encoding) *Decoder {
+	return &Decoder{
+		s:        &binary.OrderStream{Stream: s, Order: enc.Order},
+		enc:      enc,
+		MaxDepth: DefaultMaxDepth,
+		MaxLen:   binary.DefaultMaxLen,
+	}
+}
+
+// Decoder reads tags from a stream
+type Decoder struct {
+	s   *binary.OrderStream
+	enc Encoding
+
+	// MaxDepth is max depth of nested compounds and lists
+	MaxDepth int
+
+	// MaxLen is max length of strings, lists and arrays
+	// If it's 0 or less, the length is limited by only bytes left
+	MaxLen int
+}
+
+// error returns a DecodeError at the current offset
+func (d *Decoder) error(typ TagType, err error) error {
+	return &binary.DecodeError{
+		Off:  d.s.Off(),
+		Type: typ.String(),
+		Err:  err,
+	}
+}
+
+// Decode reads a named tag
+func (d *Decoder) Decode() (name string, tag Tag, err error) {
+	typ, err := d.tagType()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if typ == TagEnd {
+		return "", nil, d.error(typ, ErrUnexpectedEnd)
+	}
+
+	name, err = d.string()
+	if err != nil {
+		return "", nil, err
+	}
+
+	tag, err = d.payload(typ, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, tag, nil
+}
+
+func (d *Decoder) tagType() (TagType, error) {
+	b, err := d.s.Byte()
+	if err != nil {
+		return 0, err
+	}
+
+	typ := TagType(b)
+	if typ > TagLongArray {
+		return 0, &binary.DecodeError{Off: d.s.Off() - 1, Type: "TagType", Err: ErrUnknownTag}
+	}
+
+	return typ, nil
+}
+
+// checkLen checks a length of elements of size bytes at least
+func (d *Decoder) checkLen(typ TagType, n uint64, size int) error {
+	if d.MaxLen > 0 && n > uint64(d.MaxLen) {
+		return d.error(typ, binary.ErrTooLong)
+	}
+
+	if n*uint64(size) > uint64(d.s.Len()) {
+		return &binary.DecodeError{
+			Off:   d.s.Off(),
+			Type:  typ.String(),
+			Need:  int(n) * size,
+			Avail: d.s.Len(),
+			Err:   binary.ErrNotEnought,
+		}
+	}
+
+	return nil
+}
+
+// length reads a length of a list or an array
+func (d *Decoder) length(typ TagType, size int) (int, error) {
+	n, err := d.int()
+	if err != nil {
+		return 0, err
+	}
+
+	if n < 0 {
+		return 0, d.error(typ, ErrNegativeLength)
+	}
+
+	if err := d.checkLen(typ, uint64(n), size); err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+func (d *Decoder) string() (string, error) {
+	var n uint64
+	if d.enc.VarInt {
+		v, err := d.s.UVarInt()
+		if err != nil {
+			return "", err
+		}
+
+		n = uint64(v)
+	} else {
+		v, err := d.s.Short()
+		if err != nil {
+			return "", err
+		}
+
+		n = uint64(v)
+	}
+
+	if err := d.checkLen(TagString, n, 1); err != nil {
+		return "", err
+	}
+
+	b := d.s.Get(int(n))
+	if d.enc.ModifiedUTF8 {
+		s, err := decodeMUTF8(b)
+		if err != nil {
+			return "", &binary.DecodeError{Off: d.s.Off() - len(b), Type: TagString.String(), Err: err}
+		}
+
+		return s, nil
+	}
+
+	return string(b), nil
+}
+
+func (d *Decoder) int() (int32, error) {
+	if d.enc.VarInt {
+		return d.s.VarInt()
+	}
+
+	return d.s.Int()
+}
+
+func (d *Decoder) long() (int64, error) {
+	if d.enc.VarInt {
+		return d.s.VarLong()
+	}
+
+	return d.s.Long()
+}
+
+// sizeOf returns min size of a value of the type
+func (d *Decoder) sizeOf(typ TagType) int {
+	switch typ {
+	case TagShort:
+		return binary.ShortSize
+	case TagInt, TagLong:
+		if d.enc.VarInt {
+			return 1
+		}
+
+		if typ == TagInt {
+			return binary.IntSize
+		}
+
+		return binary.LongSize
+	case TagFloat:
+		return binary.FloatSize
+	case TagDouble:
+		return binary.DoubleSize
+	}
+
+	return 1
+}
+
+func (d *Decoder) payload(typ TagType, depth int) (Tag, error) {
+	switch typ {
+	case TagByte:
+		v, err := d.s.SByte()
+		if err != nil {
+			return nil, err
+		}
+
+		return Byte(v), nil
+	case TagShort:
+		v, err := d.s.SShort()
+		if err != nil {
+			return nil, err
+		}
+
+		return Short(v), nil
+	case TagInt:
+		v, err := d.int()
+		if err != nil {
+			return nil, err
+		}
+
+		return Int(v), nil
+	case TagLong:
+		v, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+
+		return Long(v), nil
+	case TagFloat:
+		v, err := d.s.Float()
+		if err != nil {
+			return nil, err
+		}
+
+		return Float(v), nil
+	case TagDouble:
+		v, err := d.s.Double()
+		if err != nil {
+			return nil, err
+		}
+
+		return Double(v), nil
+	case TagByteArray:
+		n, err := d.length(typ, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		v := make(ByteArray, n)
+		copy(v, d.s.Get(n))
+
+		return v, nil
+	case TagString:
+		v, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+
+		return String(v), nil
+	case TagList:
+		return d.list(depth + 1)
+	case TagCompound:
+		return d.compound(depth + 1)
+	case TagIntArray:
+		n, err := d.length(typ, d.sizeOf(TagInt))
+		if err != nil {
+			return nil, err
+		}
+
+		v := make(IntArray, n)
+		for i := range v {
+			v[i], err = d.int()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return v, nil
+	case TagLongArray:
+		n, err := d.length(typ, d.sizeOf(TagLong))
+		if err != nil {
+			return nil, err
+		}
+
+		v := make(LongArray, n)
+		for i := range v {
+			v[i], err = d.long()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return v, nil
+	case TagEnd:
+		return nil, d.error(typ, ErrUnexpectedEnd)
+	}
+
+	return nil, d.error(typ, ErrUnknownTag)
+}
+
+func (d *Decoder) list(depth int) (Tag, error) {
+	if d.MaxDepth > 0 && depth > d.MaxDepth {
+		return nil, d.error(TagList, ErrMaxDepth)
+	}
+
+	elem, err := d.tagType()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := d.length(TagList, d.sizeOf(elem))
+	if err != nil {
+		return nil, err
+	}
+
+	if elem == TagEnd && n > 0 {
+		return nil, d.error(TagList, ErrUnexpectedEnd)
+	}
+
+	list := List{
+		Elem:   elem,
+		Values: make([]Tag, n),
+	}
+
+	for i := range list.Values {
+		list.Values[i], err = d.payload(elem, depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+func (d *Decoder) compound(depth int) (Tag, error) {
+	if d.MaxDepth > 0 && depth > d.MaxDepth {
+		return nil, d.error(TagCompound, ErrMaxDepth)
+	}
+
+	c := Compound{}
+	index := map[string]int{} // for duplicated names
+	for {
+		typ, err := d.tagType()
+		if err != nil {
+			return nil, err
+		}
+
+		if typ == TagEnd {
+			return c, nil
+		}
+
+		name, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+
+		tag, err := d.payload(typ, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[name]; ok {
+			c[i].Tag = tag
+
+			continue
+		}
+
+		index[name] = len(c)
+		c = append(c, Entry{Name: name, Tag: tag})
+	}
+}
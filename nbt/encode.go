@@ -0,0 +1,194 @@
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"math"
+
+	"github.com/beito123/binary"
+)
+
+// NewEncoder returns new Encoder writing to the stream with the encoding
+func NewEncoder(s *binary.Stream, enc Encoding) *Encoder {
+	return &Encoder{
+		s:   &binary.OrderStream{Stream: s, Order: enc.Order},
+		enc: enc,
+	}
+}
+
+// Encoder writes tags to a stream
+type Encoder struct {
+	s   *binary.OrderStream
+	enc Encoding
+}
+
+// Encode writes a named tag
+func (e *Encoder) Encode(name string, tag Tag) error {
+	if tag == nil {
+		return ErrInvalidValue
+	}
+
+	e.s.PutByte(byte(tag.Type()))
+
+	err := e.string(name)
+	if err != nil {
+		return err
+	}
+
+	return e.payload(tag)
+}
+
+func (e *Encoder) string(value string) error {
+	ln := len(value)
+
+	var b []byte
+	if e.enc.ModifiedUTF8 && !isASCII(value) {
+		b = appendMUTF8(nil, value)
+		ln = len(b)
+	}
+
+	if e.enc.VarInt {
+		err := e.s.PutLength(binary.PrefixVarInt, ln)
+		if err != nil {
+			return err
+		}
+	} else {
+		if ln > 0xffff {
+			return binary.ErrPrefixOverflow
+		}
+
+		e.s.PutShort(uint16(ln))
+	}
+
+	if b != nil {
+		return e.s.Put(b)
+	}
+
+	_, err := e.s.Write([]byte(value))
+
+	return err
+}
+
+func (e *Encoder) int(v int32) {
+	if e.enc.VarInt {
+		e.s.PutVarInt(v)
+	} else {
+		e.s.PutInt(v)
+	}
+}
+
+func (e *Encoder) long(v int64) {
+	if e.enc.VarInt {
+		e.s.PutVarLong(v)
+	} else {
+		e.s.PutLong(v)
+	}
+}
+
+func (e *Encoder) length(ln int) error {
+	if int64(ln) > math.MaxInt32 {
+		return binary.ErrPrefixOverflow
+	}
+
+	e.int(int32(ln))
+
+	return nil
+}
+
+func (e *Encoder) payload(tag Tag) error {
+	switch v := tag.(type) {
+	case Byte:
+		e.s.PutSByte(int8(v))
+	case Short:
+		e.s.PutSShort(int16(v))
+	case Int:
+		e.int(int32(v))
+	case Long:
+		e.long(int64(v))
+	case Float:
+		e.s.PutFloat(float32(v))
+	case Double:
+		e.s.PutDouble(float64(v))
+	case ByteArray:
+		if err := e.length(len(v)); err != nil {
+			return err
+		}
+
+		e.s.Put(v)
+	case String:
+		return e.string(string(v))
+	case List:
+		return e.list(v)
+	case Compound:
+		return e.compound(v)
+	case IntArray:
+		if err := e.length(len(v)); err != nil {
+			return err
+		}
+
+		for _, x := range v {
+			e.int(x)
+		}
+	case LongArray:
+		if err := e.length(len(v)); err != nil {
+			return err
+		}
+
+		for _, x := range v {
+			e.long(x)
+		}
+	default:
+		return ErrUnknownTag
+	}
+
+	return nil
+}
+
+func (e *Encoder) list(list List) error {
+	e.s.PutByte(byte(list.Elem))
+
+	if err := e.length(len(list.Values)); err != nil {
+		return err
+	}
+
+	for _, v := range list.Values {
+		if v == nil || v.Type() != list.Elem {
+			return ErrListType
+		}
+
+		if err := e.payload(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Encoder) compound(c Compound) error {
+	for _, entry := range c {
+		if entry.Tag == nil {
+			return ErrInvalidValue
+		}
+
+		e.s.PutByte(byte(entry.Tag.Type()))
+
+		if err := e.string(entry.Name); err != nil {
+			return err
+		}
+
+		if err := e.payload(entry.Tag); err != nil {
+			return err
+		}
+	}
+
+	e.s.PutByte(byte(TagEnd))
+
+	return nil
+}
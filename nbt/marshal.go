@@ -0,0 +1,552 @@
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/beito123/binary"
+)
+
+// TagName is the key of struct tags used by Marshal and Unmarshal
+const TagName = "nbt"
+
+// TypeError is returned when a Go type can't be converted to a tag
+type TypeError struct {
+	Type reflect.Type
+}
+
+func (e *TypeError) Error() string {
+	return "nbt: unsupported type " + e.Type.String()
+}
+
+// UnmarshalTypeError is returned when a tag can't be stored in a Go type
+type UnmarshalTypeError struct {
+	Tag  TagType
+	Type reflect.Type
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return "nbt: can't store " + e.Tag.String() + " in " + e.Type.String()
+}
+
+// FieldError is returned when a field of a struct can't be converted
+type FieldError struct {
+	Struct string
+	Field  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return "nbt: field " + e.Struct + "." + e.Field + ": " + strings.TrimPrefix(e.Err.Error(), "nbt: ")
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Marshal encodes v as a named tag with the encoding
+//
+// Go values are converted to tags as following
+//
+//	bool, int8, uint8           TAG_Byte
+//	int16, uint16               TAG_Short
+//	int32, uint32               TAG_Int
+//	int64, uint64, int, uint    TAG_Long
+//	float32                     TAG_Float
+//	float64                     TAG_Double
+//	[]byte, []int8              TAG_Byte_Array
+//	string                      TAG_String
+//	other slices and arrays     TAG_List
+//	structs, map[string]T       TAG_Compound
+//	[]int32, []uint32           TAG_Int_Array
+//	[]int64, []uint64           TAG_Long_Array
+//
+// Tags such as Compound are used as they are.
+// Struct fields are named by the nbt key of the struct tag, e.g. `nbt:"name,omitempty"`.
+// "-" ignores the field, and omitempty omits the field if it's a zero value.
+func Marshal(enc Encoding, name string, v interface{}) ([]byte, error) {
+	tag, err := ToTag(v)
+	if err != nil {
+		return nil, err
+	}
+
+	s := binary.NewStream()
+
+	err = NewEncoder(s, enc).Encode(name, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.AllBytes(), nil
+}
+
+// Unmarshal decodes a named tag of data with the encoding and stores it in the value pointed to by v
+// It uses the default limits of Decoder.
+func Unmarshal(enc Encoding, data []byte, v interface{}) error {
+	_, tag, err := NewDecoder(binary.NewStreamBytes(data), enc).Decode()
+	if err != nil {
+		return err
+	}
+
+	return FromTag(tag, v)
+}
+
+// ToTag converts v to a tag
+func ToTag(v interface{}) (Tag, error) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return nil, ErrInvalidValue
+	}
+
+	return toTag(val)
+}
+
+// FromTag stores the tag in the value pointed to by v
+func FromTag(tag Tag, v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() || tag == nil {
+		return ErrInvalidValue
+	}
+
+	return fromTag(tag, val.Elem())
+}
+
+var tagType = reflect.TypeOf((*Tag)(nil)).Elem()
+
+// field is a field of a struct with the options
+type field struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// fields returns fields of the struct type
+func fields(typ reflect.Type) []field {
+	var fs []field
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+
+		tag := f.Tag.Get(TagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+
+		fs = append(fs, field{
+			index:     i,
+			name:      name,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+
+	return fs
+}
+
+// arrayType returns the array tag type of the element kind, or TagList
+func arrayType(elem reflect.Kind) TagType {
+	switch elem {
+	case reflect.Int8, reflect.Uint8:
+		return TagByteArray
+	case reflect.Int32, reflect.Uint32:
+		return TagIntArray
+	case reflect.Int64, reflect.Uint64:
+		return TagLongArray
+	}
+
+	return TagList
+}
+
+// typeOf returns the tag type of the Go type, or TagEnd if it's unknown
+func typeOf(typ reflect.Type) TagType {
+	// the zero value of pointers is nil, so uses a new value
+	if typ.Kind() == reflect.Ptr {
+		if typ.Implements(tagType) {
+			return reflect.New(typ.Elem()).Interface().(Tag).Type()
+		}
+
+		return typeOf(typ.Elem())
+	}
+
+	if typ.Implements(tagType) && typ.Kind() != reflect.Interface {
+		return reflect.Zero(typ).Interface().(Tag).Type()
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return TagByte
+	case reflect.Int16, reflect.Uint16:
+		return TagShort
+	case reflect.Int32, reflect.Uint32:
+		return TagInt
+	case reflect.Int64, reflect.Uint64, reflect.Int, reflect.Uint:
+		return TagLong
+	case reflect.Float32:
+		return TagFloat
+	case reflect.Float64:
+		return TagDouble
+	case reflect.String:
+		return TagString
+	case reflect.Slice, reflect.Array:
+		return arrayType(typ.Elem().Kind())
+	case reflect.Struct, reflect.Map:
+		return TagCompound
+	}
+
+	return TagEnd
+}
+
+func toTag(v reflect.Value) (Tag, error) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, ErrInvalidValue
+		}
+
+		return toTag(v.Elem())
+	}
+
+	if tag, ok := v.Interface().(Tag); ok {
+		return tag, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return Byte(1), nil
+		}
+
+		return Byte(0), nil
+	case reflect.Int8:
+		return Byte(v.Int()), nil
+	case reflect.Uint8:
+		return Byte(v.Uint()), nil
+	case reflect.Int16:
+		return Short(v.Int()), nil
+	case reflect.Uint16:
+		return Short(v.Uint()), nil
+	case reflect.Int32:
+		return Int(v.Int()), nil
+	case reflect.Uint32:
+		return Int(v.Uint()), nil
+	case reflect.Int64, reflect.Int:
+		return Long(v.Int()), nil
+	case reflect.Uint64, reflect.Uint:
+		return Long(v.Uint()), nil
+	case reflect.Float32:
+		return Float(v.Float()), nil
+	case reflect.Float64:
+		return Double(v.Float()), nil
+	case reflect.String:
+		return String(v.String()), nil
+	case reflect.Slice, reflect.Array:
+		return toArray(v)
+	case reflect.Map:
+		return toMapCompound(v)
+	case reflect.Struct:
+		return toStructCompound(v)
+	}
+
+	return nil, &TypeError{Type: v.Type()}
+}
+
+func toArray(v reflect.Value) (Tag, error) {
+	elem := v.Type().Elem()
+	signed := elem.Kind() >= reflect.Int && elem.Kind() <= reflect.Int64
+
+	switch arrayType(elem.Kind()) {
+	case TagByteArray:
+		b := make(ByteArray, v.Len())
+		for i := range b {
+			if signed {
+				b[i] = byte(v.Index(i).Int())
+			} else {
+				b[i] = byte(v.Index(i).Uint())
+			}
+		}
+
+		return b, nil
+	case TagIntArray:
+		a := make(IntArray, v.Len())
+		for i := range a {
+			if signed {
+				a[i] = int32(v.Index(i).Int())
+			} else {
+				a[i] = int32(v.Index(i).Uint())
+			}
+		}
+
+		return a, nil
+	case TagLongArray:
+		a := make(LongArray, v.Len())
+		for i := range a {
+			if signed {
+				a[i] = v.Index(i).Int()
+			} else {
+				a[i] = int64(v.Index(i).Uint())
+			}
+		}
+
+		return a, nil
+	}
+
+	list := List{
+		Elem:   typeOf(elem),
+		Values: make([]Tag, v.Len()),
+	}
+
+	for i := range list.Values {
+		tag, err := toTag(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			list.Elem = tag.Type()
+		} else if tag.Type() != list.Elem {
+			return nil, ErrListType
+		}
+
+		list.Values[i] = tag
+	}
+
+	return list, nil
+}
+
+func toMapCompound(v reflect.Value) (Tag, error) {
+	if v.Type().Key().Kind() != reflect.String {
+		return nil, &TypeError{Type: v.Type()}
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	c := make(Compound, 0, len(keys))
+	for _, key := range keys {
+		tag, err := toTag(v.MapIndex(key))
+		if err != nil {
+			return nil, err
+		}
+
+		c = append(c, Entry{Name: key.String(), Tag: tag})
+	}
+
+	return c, nil
+}
+
+func toStructCompound(v reflect.Value) (Tag, error) {
+	typ := v.Type()
+
+	var c Compound
+	for _, f := range fields(typ) {
+		fv := v.Field(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) && fv.IsNil() {
+			continue
+		}
+
+		tag, err := toTag(fv)
+		if err != nil {
+			return nil, &FieldError{Struct: typ.Name(), Field: typ.Field(f.index).Name, Err: err}
+		}
+
+		c = append(c, Entry{Name: f.name, Tag: tag})
+	}
+
+	return c, nil
+}
+
+// intOf returns the value of an integer tag
+func intOf(tag Tag) (int64, bool) {
+	switch t := tag.(type) {
+	case Byte:
+		return int64(t), true
+	case Short:
+		return int64(t), true
+	case Int:
+		return int64(t), true
+	case Long:
+		return int64(t), true
+	}
+
+	return 0, false
+}
+
+// uintOf returns the value of an integer tag as unsigned with the size of the tag
+func uintOf(tag Tag) (uint64, bool) {
+	switch t := tag.(type) {
+	case Byte:
+		return uint64(uint8(t)), true
+	case Short:
+		return uint64(uint16(t)), true
+	case Int:
+		return uint64(uint32(t)), true
+	case Long:
+		return uint64(t), true
+	}
+
+	return 0, false
+}
+
+// elements returns values of a list or an array
+func elements(tag Tag) ([]Tag, bool) {
+	switch t := tag.(type) {
+	case List:
+		return t.Values, true
+	case ByteArray:
+		values := make([]Tag, len(t))
+		for i, x := range t {
+			values[i] = Byte(x)
+		}
+
+		return values, true
+	case IntArray:
+		values := make([]Tag, len(t))
+		for i, x := range t {
+			values[i] = Int(x)
+		}
+
+		return values, true
+	case LongArray:
+		values := make([]Tag, len(t))
+		for i, x := range t {
+			values[i] = Long(x)
+		}
+
+		return values, true
+	}
+
+	return nil, false
+}
+
+func fromTag(tag Tag, v reflect.Value) error {
+	typ := v.Type()
+
+	// Tag, interface{} and tag types
+	if reflect.TypeOf(tag).AssignableTo(typ) {
+		v.Set(reflect.ValueOf(tag))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(typ.Elem()))
+		}
+
+		return fromTag(tag, v.Elem())
+	case reflect.Bool:
+		if n, ok := intOf(tag); ok {
+			v.SetBool(n != 0)
+
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := intOf(tag); ok && !v.OverflowInt(n) {
+			v.SetInt(n)
+
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := uintOf(tag); ok && !v.OverflowUint(n) {
+			v.SetUint(n)
+
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch t := tag.(type) {
+		case Float:
+			v.SetFloat(float64(t))
+
+			return nil
+		case Double:
+			v.SetFloat(float64(t))
+
+			return nil
+		}
+	case reflect.String:
+		if s, ok := tag.(String); ok {
+			v.SetString(string(s))
+
+			return nil
+		}
+	case reflect.Slice:
+		if values, ok := elements(tag); ok {
+			s := reflect.MakeSlice(typ, len(values), len(values))
+			for i, x := range values {
+				if err := fromTag(x, s.Index(i)); err != nil {
+					return err
+				}
+			}
+
+			v.Set(s)
+
+			return nil
+		}
+	case reflect.Array:
+		if values, ok := elements(tag); ok && len(values) == v.Len() {
+			for i, x := range values {
+				if err := fromTag(x, v.Index(i)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	case reflect.Map:
+		if c, ok := tag.(Compound); ok && typ.Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(typ, len(c))
+			for _, e := range c {
+				elem := reflect.New(typ.Elem()).Elem()
+				if err := fromTag(e.Tag, elem); err != nil {
+					return err
+				}
+
+				m.SetMapIndex(reflect.ValueOf(e.Name).Convert(typ.Key()), elem)
+			}
+
+			v.Set(m)
+
+			return nil
+		}
+	case reflect.Struct:
+		if c, ok := tag.(Compound); ok {
+			for _, f := range fields(typ) {
+				t := c.Get(f.name)
+				if t == nil {
+					continue
+				}
+
+				if err := fromTag(t, v.Field(f.index)); err != nil {
+					return &FieldError{Struct: typ.Name(), Field: typ.Field(f.index).Name, Err: err}
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return &UnmarshalTypeError{Tag: tag.Type(), Type: typ}
+}
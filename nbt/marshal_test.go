@@ -0,0 +1,165 @@
+package nbt
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testPos struct {
+	X, Y, Z int32
+}
+
+type testPlayer struct {
+	Name      string            `nbt:"name"`
+	Health    float32           `nbt:"health"`
+	OnGround  bool              `nbt:"onGround"`
+	XP        int64             `nbt:"xp,omitempty"`
+	Pos       testPos           `nbt:"pos"`
+	Inventory []string          `nbt:"inventory"`
+	Data      []byte            `nbt:"data"`
+	Scores    []int32           `nbt:"scores"`
+	Tags      map[string]uint16 `nbt:"tags"`
+	Extra     Tag               `nbt:"extra"`
+	Ignored   int               `nbt:"-"`
+}
+
+func TestMarshal(t *testing.T) {
+	player := testPlayer{
+		Name:      "beito",
+		Health:    20,
+		OnGround:  true,
+		Pos:       testPos{X: 1, Y: 64, Z: -1},
+		Inventory: []string{"stone", "dirt"},
+		Data:      []byte{0xff},
+		Scores:    []int32{1, 2},
+		Tags:      map[string]uint16{"b": 2, "a": 65535},
+		Extra:     Compound{{Name: "v", Tag: Byte(1)}},
+		Ignored:   1,
+	}
+
+	tag, err := ToTag(player)
+	if err != nil {
+		t.Fatalf("Failed to convert Error: %s", err)
+	}
+
+	exp := Compound{
+		{Name: "name", Tag: String("beito")},
+		{Name: "health", Tag: Float(20)},
+		{Name: "onGround", Tag: Byte(1)},
+		{Name: "pos", Tag: Compound{{Name: "X", Tag: Int(1)}, {Name: "Y", Tag: Int(64)}, {Name: "Z", Tag: Int(-1)}}},
+		{Name: "inventory", Tag: List{Elem: TagString, Values: []Tag{String("stone"), String("dirt")}}},
+		{Name: "data", Tag: ByteArray{0xff}},
+		{Name: "scores", Tag: IntArray{1, 2}},
+		{Name: "tags", Tag: Compound{{Name: "a", Tag: Short(-1)}, {Name: "b", Tag: Short(2)}}},
+		{Name: "extra", Tag: Compound{{Name: "v", Tag: Byte(1)}}},
+	}
+
+	if !reflect.DeepEqual(tag, exp) {
+		t.Fatalf("Expected %#v for tag, but %#v", exp, tag)
+	}
+
+	for _, enc := range []Encoding{BigEndian, LittleEndian, NetworkLittleEndian} {
+		b, err := Marshal(enc, "player", player)
+		if err != nil {
+			t.Fatalf("Failed to marshal Error: %s", err)
+		}
+
+		var got testPlayer
+		err = Unmarshal(enc, b, &got)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal Error: %s", err)
+		}
+
+		player.Ignored = 0
+		if !reflect.DeepEqual(got, player) {
+			t.Fatalf("Expected %+v for value, but %+v", player, got)
+		}
+	}
+}
+
+type testPointers struct {
+	Pos   *testPos    `nbt:"pos"`
+	Extra *Compound   `nbt:"extra"`
+	Empty []*Compound `nbt:"empty"`
+	Items []*Compound `nbt:"items"`
+}
+
+func TestMarshalPointers(t *testing.T) {
+	v := testPointers{
+		Pos:   &testPos{X: 1, Y: 2, Z: 3},
+		Extra: &Compound{{Name: "v", Tag: Byte(1)}},
+		Empty: []*Compound{},
+		Items: []*Compound{{{Name: "id", Tag: Short(1)}}},
+	}
+
+	tag, err := ToTag(v)
+	if err != nil {
+		t.Fatalf("Failed to convert Error: %s", err)
+	}
+
+	exp := Compound{
+		{Name: "pos", Tag: Compound{{Name: "X", Tag: Int(1)}, {Name: "Y", Tag: Int(2)}, {Name: "Z", Tag: Int(3)}}},
+		{Name: "extra", Tag: Compound{{Name: "v", Tag: Byte(1)}}},
+		{Name: "empty", Tag: List{Elem: TagCompound, Values: []Tag{}}},
+		{Name: "items", Tag: List{Elem: TagCompound, Values: []Tag{Compound{{Name: "id", Tag: Short(1)}}}}},
+	}
+
+	if !reflect.DeepEqual(tag, exp) {
+		t.Fatalf("Expected %#v for tag, but %#v", exp, tag)
+	}
+
+	b, err := Marshal(BigEndian, "", v)
+	if err != nil {
+		t.Fatalf("Failed to marshal Error: %s", err)
+	}
+
+	var got testPointers
+	err = Unmarshal(BigEndian, b, &got)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, v) {
+		t.Fatalf("Expected %+v for value, but %+v", v, got)
+	}
+}
+
+func TestUnmarshalError(t *testing.T) {
+	var v struct {
+		A int8 `nbt:"a"`
+	}
+
+	err := FromTag(Compound{{Name: "a", Tag: Int(300)}}, &v)
+
+	var terr *UnmarshalTypeError
+	if !errors.As(err, &terr) || terr.Tag != TagInt {
+		t.Fatalf("Expected an UnmarshalTypeError, but %v", err)
+	}
+
+	var ferr *FieldError
+	if !errors.As(err, &ferr) || ferr.Field != "A" {
+		t.Fatalf("Expected a FieldError, but %v", err)
+	}
+
+	err = FromTag(Int(1), v)
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidValue, err)
+	}
+
+	_, err = ToTag(make(chan int))
+
+	var typeErr *TypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected a TypeError, but %v", err)
+	}
+}
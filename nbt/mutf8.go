@@ -0,0 +1,117 @@
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Modified UTF-8 is UTF-8 of Java
+// The null character is 2 bytes (0xc0 0x80), and supplementary characters are surrogate pairs of 3 bytes each.
+
+// isASCII returns whether s is the same in UTF-8 and modified UTF-8
+func isASCII[T string | []byte](s T) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0 || s[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appendMUTF8 appends s as modified UTF-8
+func appendMUTF8(dst []byte, s string) []byte {
+	if isASCII(s) {
+		return append(dst, s...)
+	}
+
+	for _, r := range s {
+		switch {
+		case r == 0:
+			dst = append(dst, 0xc0, 0x80)
+		case r < 0x80:
+			dst = append(dst, byte(r))
+		case r < 0x800:
+			dst = append(dst, 0xc0|byte(r>>6), 0x80|byte(r)&0x3f)
+		case r < 0x10000:
+			dst = appendMUTF8Char(dst, r)
+		default:
+			r1, r2 := utf16.EncodeRune(r)
+			dst = appendMUTF8Char(appendMUTF8Char(dst, r1), r2)
+		}
+	}
+
+	return dst
+}
+
+// appendMUTF8Char appends a character as 3 bytes
+func appendMUTF8Char(dst []byte, r rune) []byte {
+	return append(dst, 0xe0|byte(r>>12), 0x80|byte(r>>6)&0x3f, 0x80|byte(r)&0x3f)
+}
+
+// decodeMUTF8 returns a string of modified UTF-8
+func decodeMUTF8(b []byte) (string, error) {
+	if isASCII(b) {
+		return string(b), nil
+	}
+
+	s := make([]byte, 0, len(b))
+	for i := 0; i < len(b); {
+		r, n := decodeMUTF8Char(b[i:])
+		if n == 0 {
+			return "", ErrInvalidString
+		}
+
+		i += n
+
+		if utf16.IsSurrogate(r) {
+			r2, n2 := decodeMUTF8Char(b[i:])
+			if n2 > 0 {
+				if pr := utf16.DecodeRune(r, r2); pr != utf8.RuneError {
+					r = pr
+					i += n2
+				}
+			}
+		}
+
+		s = utf8.AppendRune(s, r)
+	}
+
+	return string(s), nil
+}
+
+// decodeMUTF8Char returns a character and its size, or 0 for the size if it's invalid
+func decodeMUTF8Char(b []byte) (rune, int) {
+	if len(b) == 0 {
+		return 0, 0
+	}
+
+	c := b[0]
+	switch {
+	case c < 0x80:
+		return rune(c), 1
+	case c&0xe0 == 0xc0:
+		if len(b) < 2 || b[1]&0xc0 != 0x80 {
+			return 0, 0
+		}
+
+		return rune(c&0x1f)<<6 | rune(b[1]&0x3f), 2
+	case c&0xf0 == 0xe0:
+		if len(b) < 3 || b[1]&0xc0 != 0x80 || b[2]&0xc0 != 0x80 {
+			return 0, 0
+		}
+
+		return rune(c&0x0f)<<12 | rune(b[1]&0x3f)<<6 | rune(b[2]&0x3f), 3
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,112 @@
+// Package nbt implements NBT (Named Binary Tag) used by Minecraft
+//
+// Java Edition uses big-endian NBT, and Bedrock Edition uses little-endian NBT.
+// Bedrock Edition also uses little-endian NBT with varints in the network protocol.
+// They are handled by an Encoding with the same implementation.
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"errors"
+
+	"github.com/beito123/binary"
+)
+
+var (
+	// ErrMaxDepth is returned when compounds and lists are nested over the max depth
+	ErrMaxDepth = errors.New("nbt: max depth exceeded")
+
+	// ErrUnknownTag is returned when a tag type is unknown
+	ErrUnknownTag = errors.New("nbt: unknown tag type")
+
+	// ErrUnexpectedEnd is returned when TAG_End is used as a value
+	ErrUnexpectedEnd = errors.New("nbt: unexpected TAG_End")
+
+	// ErrNegativeLength is returned when a length of a list or an array is negative
+	ErrNegativeLength = errors.New("nbt: negative length")
+
+	// ErrListType is returned when an element of a list doesn't match the type of the list
+	ErrListType = errors.New("nbt: element type doesn't match the list")
+
+	// ErrInvalidString is returned when a string isn't valid modified UTF-8
+	ErrInvalidString = errors.New("nbt: invalid modified UTF-8")
+
+	// ErrInvalidValue is returned when a nil value is passed to Marshal, or a non-pointer to Unmarshal
+	ErrInvalidValue = errors.New("nbt: invalid value (nil or not a pointer)")
+)
+
+// TagType is a type of tags
+type TagType byte
+
+const (
+	TagEnd TagType = iota
+	TagByte
+	TagShort
+	TagInt
+	TagLong
+	TagFloat
+	TagDouble
+	TagByteArray
+	TagString
+	TagList
+	TagCompound
+	TagIntArray
+	TagLongArray
+)
+
+var tagNames = [...]string{
+	TagEnd:       "TAG_End",
+	TagByte:      "TAG_Byte",
+	TagShort:     "TAG_Short",
+	TagInt:       "TAG_Int",
+	TagLong:      "TAG_Long",
+	TagFloat:     "TAG_Float",
+	TagDouble:    "TAG_Double",
+	TagByteArray: "TAG_Byte_Array",
+	TagString:    "TAG_String",
+	TagList:      "TAG_List",
+	TagCompound:  "TAG_Compound",
+	TagIntArray:  "TAG_Int_Array",
+	TagLongArray: "TAG_Long_Array",
+}
+
+// String returns name of the tag type
+func (t TagType) String() string {
+	if int(t) < len(tagNames) {
+		return tagNames[t]
+	}
+
+	return "TAG_Unknown"
+}
+
+// Encoding is a format of NBT
+type Encoding struct {
+	// Order is the byte order of numbers
+	Order binary.Order
+
+	// VarInt encodes ints, longs and lengths as varints
+	// Lengths of strings are unsigned varints, and others are signed (ZigZag) varints.
+	VarInt bool
+
+	// ModifiedUTF8 encodes strings as modified UTF-8 of Java
+	ModifiedUTF8 bool
+}
+
+var (
+	// BigEndian is the encoding of Java Edition
+	BigEndian = Encoding{Order: binary.BigEndian, ModifiedUTF8: true}
+
+	// LittleEndian is the encoding of Bedrock Edition files such as level.dat
+	LittleEndian = Encoding{Order: binary.LittleEndian}
+
+	// NetworkLittleEndian is the encoding of Bedrock Edition network protocol
+	NetworkLittleEndian = Encoding{Order: binary.LittleEndian, VarInt: true}
+)
@@ -0,0 +1,187 @@
+package nbt
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/beito123/binary"
+)
+
+// helloWorld is hello_world.nbt of the NBT specification
+var helloWorld = []byte{
+	0x0a, 0x00, 0x0b, 'h', 'e', 'l', 'l', 'o', ' ', 'w', 'o', 'r', 'l', 'd',
+	0x08, 0x00, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x09, 'B', 'a', 'n', 'a', 'n', 'r', 'a', 'm', 'a',
+	0x00,
+}
+
+func TestDecodeHelloWorld(t *testing.T) {
+	name, tag, err := NewDecoder(binary.NewStreamBytes(helloWorld), BigEndian).Decode()
+	if err != nil {
+		t.Fatalf("Failed to decode Error: %s", err)
+	}
+
+	if name != "hello world" {
+		t.Fatalf("Expected %s for name, but %s", "hello world", name)
+	}
+
+	exp := Compound{{Name: "name", Tag: String("Bananrama")}}
+	if !reflect.DeepEqual(tag, exp) {
+		t.Fatalf("Expected %#v for tag, but %#v", exp, tag)
+	}
+
+	s := binary.NewStream()
+	err = NewEncoder(s, BigEndian).Encode(name, tag)
+	if err != nil {
+		t.Fatalf("Failed to encode Error: %s", err)
+	}
+
+	if !bytes.Equal(s.AllBytes(), helloWorld) {
+		t.Fatalf("Expected % x for bytes, but % x", helloWorld, s.AllBytes())
+	}
+}
+
+func TestEncodeNetwork(t *testing.T) {
+	tag := Compound{
+		{Name: "a", Tag: Int(-2)},
+		{Name: "b", Tag: Short(1)},
+		{Name: "c", Tag: List{Elem: TagLong, Values: []Tag{Long(150)}}},
+	}
+
+	s := binary.NewStream()
+	err := NewEncoder(s, NetworkLittleEndian).Encode("", tag)
+	if err != nil {
+		t.Fatalf("Failed to encode Error: %s", err)
+	}
+
+	exp := []byte{
+		0x0a, 0x00, // root
+		0x03, 0x01, 'a', 0x03, // int -2 as zigzag varint
+		0x02, 0x01, 'b', 0x01, 0x00, // short in little endian
+		0x09, 0x01, 'c', 0x04, 0x02, 0xac, 0x02, // list of a long 150
+		0x00,
+	}
+
+	if !bytes.Equal(s.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, s.AllBytes())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tag := Compound{
+		{Name: "byte", Tag: Byte(-1)},
+		{Name: "short", Tag: Short(-300)},
+		{Name: "int", Tag: Int(-70000)},
+		{Name: "long", Tag: Long(-1 << 40)},
+		{Name: "float", Tag: Float(1.5)},
+		{Name: "double", Tag: Double(-2.25)},
+		{Name: "bytes", Tag: ByteArray{0x00, 0xff}},
+		{Name: "string", Tag: String("あ\x00😀")},
+		{Name: "list", Tag: List{Elem: TagCompound, Values: []Tag{Compound{}, Compound{{Name: "x", Tag: Byte(1)}}}}},
+		{Name: "empty", Tag: List{Elem: TagEnd, Values: []Tag{}}},
+		{Name: "ints", Tag: IntArray{1, -1}},
+		{Name: "longs", Tag: LongArray{1 << 50, -1}},
+	}
+
+	for _, enc := range []Encoding{BigEndian, LittleEndian, NetworkLittleEndian} {
+		s := binary.NewStream()
+		err := NewEncoder(s, enc).Encode("root", tag)
+		if err != nil {
+			t.Fatalf("Failed to encode Error: %s", err)
+		}
+
+		name, got, err := NewDecoder(s, enc).Decode()
+		if err != nil {
+			t.Fatalf("Failed to decode Error: %s", err)
+		}
+
+		if name != "root" || !reflect.DeepEqual(got, tag) {
+			t.Fatalf("Expected %#v for tag, but %#v", tag, got)
+		}
+
+		if s.Len() != 0 {
+			t.Fatalf("Expected %d for len, but %d", 0, s.Len())
+		}
+	}
+}
+
+func TestModifiedUTF8(t *testing.T) {
+	s := binary.NewStream()
+	NewEncoder(s, BigEndian).Encode("\x00😀", Byte(0))
+
+	exp := []byte{0x01, 0x00, 0x08, 0xc0, 0x80, 0xed, 0xa0, 0xbd, 0xed, 0xb8, 0x80, 0x00}
+	if !bytes.Equal(s.AllBytes(), exp) {
+		t.Fatalf("Expected % x for bytes, but % x", exp, s.AllBytes())
+	}
+
+	_, err := decodeMUTF8([]byte{0xc0})
+	if !errors.Is(err, ErrInvalidString) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidString, err)
+	}
+}
+
+func TestDecodeLimit(t *testing.T) {
+	// nested lists
+	s := binary.NewStream()
+	s.PutByte(byte(TagList))
+	s.PutShort(0)
+	for i := 0; i < 10; i++ {
+		s.PutByte(byte(TagList))
+		s.PutInt(1)
+	}
+
+	dec := NewDecoder(s, BigEndian)
+	dec.MaxDepth = 5
+
+	_, _, err := dec.Decode()
+	if !errors.Is(err, ErrMaxDepth) {
+		t.Fatalf("Expected %v, but %v", ErrMaxDepth, err)
+	}
+
+	// a huge length without bytes
+	b := []byte{byte(TagIntArray), 0x00, 0x00, 0x7f, 0xff, 0xff, 0xff}
+
+	dec = NewDecoder(binary.NewStreamBytes(b), BigEndian)
+	dec.MaxLen = 0
+
+	_, _, err = dec.Decode()
+	if !errors.Is(err, binary.ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", binary.ErrNotEnought, err)
+	}
+
+	_, _, err = NewDecoder(binary.NewStreamBytes(b), BigEndian).Decode()
+	if !errors.Is(err, binary.ErrTooLong) {
+		t.Fatalf("Expected %v, but %v", binary.ErrTooLong, err)
+	}
+
+	// negative length
+	b = []byte{byte(TagByteArray), 0x00, 0x00, 0xff, 0xff, 0xff, 0xff}
+
+	_, _, err = NewDecoder(binary.NewStreamBytes(b), BigEndian).Decode()
+	if !errors.Is(err, ErrNegativeLength) {
+		t.Fatalf("Expected %v, but %v", ErrNegativeLength, err)
+	}
+
+	// unknown tag
+	_, _, err = NewDecoder(binary.NewStreamBytes([]byte{0x0d}), BigEndian).Decode()
+	if !errors.Is(err, ErrUnknownTag) {
+		t.Fatalf("Expected %v, but %v", ErrUnknownTag, err)
+	}
+}
+
+func TestEncodeListType(t *testing.T) {
+	err := NewEncoder(binary.NewStream(), BigEndian).Encode("", List{Elem: TagInt, Values: []Tag{Long(1)}})
+	if !errors.Is(err, ErrListType) {
+		t.Fatalf("Expected %v, but %v", ErrListType, err)
+	}
+}
@@ -0,0 +1,684 @@
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SNBT is the stringified NBT used in commands
+//
+//	{Name:"x",Count:3b,Pos:[1.0d,2.0d],Data:[I;1,2,3]}
+//
+// Numbers have a suffix of the type: b (byte), s (short), L (long), f (float) and d (double).
+// Numbers without a suffix are int, or double if they have a decimal point or an exponent.
+// true and false are bytes of 1 and 0.
+// An empty list is TAG_List of TAG_End, because it has no elements to decide the type.
+
+// SyntaxError is returned when SNBT is invalid
+type SyntaxError struct {
+	Line   int // line number from 1
+	Column int // column number (count of characters) from 1
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return "nbt: snbt:" + strconv.Itoa(e.Line) + ":" + strconv.Itoa(e.Column) + ": " + e.Msg
+}
+
+// ParseSNBT parses a tag of SNBT
+func ParseSNBT(s string) (Tag, error) {
+	p := &snbtParser{
+		s:    s,
+		line: 1,
+		col:  1,
+	}
+
+	tag, err := p.value(0)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, p.error("unexpected " + strconv.QuoteRune(p.peek()) + " after the value")
+	}
+
+	return tag, nil
+}
+
+type snbtParser struct {
+	s    string
+	pos  int
+	line int
+	col  int
+}
+
+func (p *snbtParser) error(msg string) error {
+	return &SyntaxError{Line: p.line, Column: p.col, Msg: msg}
+}
+
+// peek returns the next character, or -1 at the end
+func (p *snbtParser) peek() rune {
+	if p.pos >= len(p.s) {
+		return -1
+	}
+
+	r, _ := utf8.DecodeRuneInString(p.s[p.pos:])
+
+	return r
+}
+
+func (p *snbtParser) next() rune {
+	if p.pos >= len(p.s) {
+		return -1
+	}
+
+	r, n := utf8.DecodeRuneInString(p.s[p.pos:])
+	p.pos += n
+
+	if r == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+
+	return r
+}
+
+func (p *snbtParser) skipSpace() {
+	for {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.next()
+		default:
+			return
+		}
+	}
+}
+
+// expect skips spaces and reads the character c
+func (p *snbtParser) expect(c rune) error {
+	p.skipSpace()
+
+	if r := p.peek(); r != c {
+		if r < 0 {
+			return p.error("expected " + strconv.QuoteRune(c) + ", but end of input")
+		}
+
+		return p.error("expected " + strconv.QuoteRune(c) + ", but " + strconv.QuoteRune(r))
+	}
+
+	p.next()
+
+	return nil
+}
+
+// isUnquoted returns whether c can be used in unquoted strings
+func isUnquoted(c rune) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' ||
+		c == '_' || c == '-' || c == '.' || c == '+'
+}
+
+func (p *snbtParser) value(depth int) (Tag, error) {
+	p.skipSpace()
+
+	switch p.peek() {
+	case '{':
+		return p.compound(depth + 1)
+	case '[':
+		return p.list(depth + 1)
+	case '"', '\'':
+		s, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+
+		return String(s), nil
+	case -1:
+		return nil, p.error("expected a value, but end of input")
+	}
+
+	line, col := p.line, p.col
+
+	s := p.unquoted()
+	if s == "" {
+		return nil, p.error("unexpected " + strconv.QuoteRune(p.peek()))
+	}
+
+	tag, err := parseScalar(s)
+	if err != nil {
+		return nil, &SyntaxError{Line: line, Column: col, Msg: err.Error()}
+	}
+
+	return tag, nil
+}
+
+func (p *snbtParser) unquoted() string {
+	start := p.pos
+	for isUnquoted(p.peek()) {
+		p.next()
+	}
+
+	return p.s[start:p.pos]
+}
+
+func (p *snbtParser) quoted() (string, error) {
+	q := p.next()
+
+	var b strings.Builder
+	for {
+		r := p.next()
+		switch r {
+		case -1:
+			return "", p.error("unterminated string")
+		case q:
+			return b.String(), nil
+		case '\\':
+			e := p.next()
+			switch e {
+			case '\\', '"', '\'':
+				b.WriteRune(e)
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				return "", p.error("invalid escape " + strconv.QuoteRune(e))
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+func (p *snbtParser) key() (string, error) {
+	p.skipSpace()
+
+	switch p.peek() {
+	case '"', '\'':
+		return p.quoted()
+	}
+
+	s := p.unquoted()
+	if s == "" {
+		if p.peek() < 0 {
+			return "", p.error("expected a key, but end of input")
+		}
+
+		return "", p.error("expected a key, but " + strconv.QuoteRune(p.peek()))
+	}
+
+	return s, nil
+}
+
+func (p *snbtParser) compound(depth int) (Tag, error) {
+	if depth > DefaultMaxDepth {
+		return nil, p.error("too deep")
+	}
+
+	p.next() // {
+
+	c := Compound{}
+
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.next()
+
+		return c, nil
+	}
+
+	for {
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(':'); err != nil {
+			return nil, err
+		}
+
+		tag, err := p.value(depth)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Set(name, tag)
+
+		p.skipSpace()
+		switch p.next() {
+		case ',':
+			continue
+		case '}':
+			return c, nil
+		case -1:
+			return nil, p.error("expected ',' or '}', but end of input")
+		default:
+			return nil, p.error("expected ',' or '}'")
+		}
+	}
+}
+
+// arrayTypes is types of typed arrays
+var arrayTypes = map[string]TagType{
+	"B": TagByteArray,
+	"I": TagIntArray,
+	"L": TagLongArray,
+}
+
+func (p *snbtParser) list(depth int) (Tag, error) {
+	if depth > DefaultMaxDepth {
+		return nil, p.error("too deep")
+	}
+
+	p.next() // [
+
+	// typed arrays such as [I;1,2]
+	var array TagType
+	if p.pos+1 < len(p.s) && p.s[p.pos+1] == ';' {
+		typ, ok := arrayTypes[p.s[p.pos:p.pos+1]]
+		if !ok {
+			return nil, p.error("unknown array type " + strconv.Quote(p.s[p.pos:p.pos+1]))
+		}
+
+		array = typ
+		p.next()
+		p.next()
+	}
+
+	var values []Tag
+
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.next()
+	} else {
+		for {
+			p.skipSpace()
+			line, col := p.line, p.col
+
+			tag, err := p.value(depth)
+			if err != nil {
+				return nil, err
+			}
+
+			if (array == 0 && len(values) > 0 && tag.Type() != values[0].Type()) || (array != 0 && !arrayElem(array, tag)) {
+				return nil, &SyntaxError{Line: line, Column: col, Msg: "mixed types in a list (" + tag.Type().String() + ")"}
+			}
+
+			values = append(values, tag)
+
+			p.skipSpace()
+			r := p.next()
+			if r == ']' {
+				break
+			}
+
+			if r != ',' {
+				if r < 0 {
+					return nil, p.error("expected ',' or ']', but end of input")
+				}
+
+				return nil, p.error("expected ',' or ']'")
+			}
+		}
+	}
+
+	switch array {
+	case TagByteArray:
+		b := make(ByteArray, len(values))
+		for i, v := range values {
+			n, _ := intOf(v)
+			b[i] = byte(n)
+		}
+
+		return b, nil
+	case TagIntArray:
+		a := make(IntArray, len(values))
+		for i, v := range values {
+			n, _ := intOf(v)
+			a[i] = int32(n)
+		}
+
+		return a, nil
+	case TagLongArray:
+		a := make(LongArray, len(values))
+		for i, v := range values {
+			n, _ := intOf(v)
+			a[i] = n
+		}
+
+		return a, nil
+	}
+
+	list := List{
+		Elem:   TagEnd,
+		Values: []Tag{},
+	}
+
+	if len(values) > 0 {
+		list.Elem = values[0].Type()
+		list.Values = values
+	}
+
+	return list, nil
+}
+
+// arrayElem returns whether the tag can be an element of the array
+// Elements without a suffix are parsed as int, so they are allowed for all arrays.
+func arrayElem(array TagType, tag Tag) bool {
+	switch v := tag.(type) {
+	case Int:
+		return array != TagByteArray || (v >= math.MinInt8 && v <= math.MaxInt8)
+	case Byte:
+		return array == TagByteArray
+	case Long:
+		return array == TagLongArray
+	}
+
+	return false
+}
+
+// isNumber returns whether s is a decimal number (digits with an optional sign, a decimal point and an exponent)
+func isNumber(s string, integer bool) bool {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+
+	digits, dot, exp := 0, false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && !dot && !exp && !integer:
+			dot = true
+		case (c == 'e' || c == 'E') && !exp && digits > 0 && !integer:
+			exp = true
+			digits = 0
+			if i+1 < len(s) && (s[i+1] == '-' || s[i+1] == '+') {
+				i++
+			}
+		default:
+			return false
+		}
+	}
+
+	return digits > 0
+}
+
+// errRange is returned when a number is out of the range of the type
+type errRange string
+
+func (e errRange) Error() string {
+	return "number " + strconv.Quote(string(e)) + " is out of range"
+}
+
+// parseScalar parses a number, a bool or an unquoted string
+func parseScalar(s string) (Tag, error) {
+	switch s {
+	case "true":
+		return Byte(1), nil
+	case "false":
+		return Byte(0), nil
+	}
+
+	body, suffix := s[:len(s)-1], s[len(s)-1]
+	switch suffix {
+	case 'b', 'B', 's', 'S', 'l', 'L':
+		if !isNumber(body, true) {
+			break
+		}
+
+		bits := map[byte]int{'b': 8, 'B': 8, 's': 16, 'S': 16, 'l': 64, 'L': 64}[suffix]
+
+		n, err := strconv.ParseInt(body, 10, bits)
+		if err != nil {
+			return nil, errRange(s)
+		}
+
+		switch bits {
+		case 8:
+			return Byte(n), nil
+		case 16:
+			return Short(n), nil
+		}
+
+		return Long(n), nil
+	case 'f', 'F', 'd', 'D':
+		if !isNumber(body, false) {
+			break
+		}
+
+		if suffix == 'f' || suffix == 'F' {
+			f, err := strconv.ParseFloat(body, 32)
+			if err != nil {
+				return nil, errRange(s)
+			}
+
+			return Float(f), nil
+		}
+
+		f, err := strconv.ParseFloat(body, 64)
+		if err != nil {
+			return nil, errRange(s)
+		}
+
+		return Double(f), nil
+	}
+
+	if isNumber(s, true) {
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, errRange(s)
+		}
+
+		return Int(n), nil
+	}
+
+	if isNumber(s, false) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errRange(s)
+		}
+
+		return Double(f), nil
+	}
+
+	return String(s), nil
+}
+
+// FormatSNBT returns the tag as SNBT
+// If indent isn't empty, compounds and lists are written in multiple lines with the indent.
+// Float and double must be finite numbers to parse them again.
+func FormatSNBT(tag Tag, indent string) string {
+	f := &snbtFormatter{
+		indent: indent,
+	}
+
+	f.tag(tag, 0)
+
+	return f.b.String()
+}
+
+type snbtFormatter struct {
+	b      strings.Builder
+	indent string
+}
+
+// newline writes a newline and the indent of the depth
+func (f *snbtFormatter) newline(depth int) {
+	if f.indent == "" {
+		return
+	}
+
+	f.b.WriteByte('\n')
+	for i := 0; i < depth; i++ {
+		f.b.WriteString(f.indent)
+	}
+}
+
+// separator writes a separator of elements
+func (f *snbtFormatter) separator(depth int) {
+	f.b.WriteByte(',')
+	f.newline(depth)
+}
+
+// space writes a space if indent is used
+func (f *snbtFormatter) space() {
+	if f.indent != "" {
+		f.b.WriteByte(' ')
+	}
+}
+
+func formatFloat(v float64, bits int) string {
+	s := strconv.FormatFloat(v, 'g', -1, bits)
+	if !math.IsInf(v, 0) && !math.IsNaN(v) && !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+
+	return s
+}
+
+// quote returns s in double quotes
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// key returns the name unquoted if possible
+func key(name string) string {
+	if name == "" {
+		return `""`
+	}
+
+	for _, r := range name {
+		if !isUnquoted(r) {
+			return quote(name)
+		}
+	}
+
+	return name
+}
+
+func (f *snbtFormatter) tag(tag Tag, depth int) {
+	switch v := tag.(type) {
+	case Byte:
+		f.b.WriteString(strconv.FormatInt(int64(v), 10) + "b")
+	case Short:
+		f.b.WriteString(strconv.FormatInt(int64(v), 10) + "s")
+	case Int:
+		f.b.WriteString(strconv.FormatInt(int64(v), 10))
+	case Long:
+		f.b.WriteString(strconv.FormatInt(int64(v), 10) + "L")
+	case Float:
+		f.b.WriteString(formatFloat(float64(v), 32) + "f")
+	case Double:
+		f.b.WriteString(formatFloat(float64(v), 64) + "d")
+	case String:
+		f.b.WriteString(quote(string(v)))
+	case ByteArray:
+		f.b.WriteString("[B;")
+		for i, x := range v {
+			if i > 0 {
+				f.b.WriteByte(',')
+				f.space()
+			}
+
+			f.b.WriteString(strconv.Itoa(int(int8(x))) + "b")
+		}
+
+		f.b.WriteByte(']')
+	case IntArray:
+		f.b.WriteString("[I;")
+		for i, x := range v {
+			if i > 0 {
+				f.b.WriteByte(',')
+				f.space()
+			}
+
+			f.b.WriteString(strconv.FormatInt(int64(x), 10))
+		}
+
+		f.b.WriteByte(']')
+	case LongArray:
+		f.b.WriteString("[L;")
+		for i, x := range v {
+			if i > 0 {
+				f.b.WriteByte(',')
+				f.space()
+			}
+
+			f.b.WriteString(strconv.FormatInt(x, 10) + "L")
+		}
+
+		f.b.WriteByte(']')
+	case List:
+		f.b.WriteByte('[')
+		for i, x := range v.Values {
+			if i > 0 {
+				f.separator(depth + 1)
+			} else {
+				f.newline(depth + 1)
+			}
+
+			f.tag(x, depth+1)
+		}
+
+		if len(v.Values) > 0 {
+			f.newline(depth)
+		}
+
+		f.b.WriteByte(']')
+	case Compound:
+		f.b.WriteByte('{')
+		for i, e := range v {
+			if i > 0 {
+				f.separator(depth + 1)
+			} else {
+				f.newline(depth + 1)
+			}
+
+			f.b.WriteString(key(e.Name) + ":")
+			f.space()
+			f.tag(e.Tag, depth+1)
+		}
+
+		if len(v) > 0 {
+			f.newline(depth)
+		}
+
+		f.b.WriteByte('}')
+	}
+}
@@ -0,0 +1,114 @@
+package nbt
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSNBT(t *testing.T) {
+	tag, err := ParseSNBT(`{Name:"x",Count:3b,Pos:[1.0d,2.0d], 'quoted key' : [I; 1, 2, 3], big: 1.5e3, flag: true, id: minecraft}`)
+	if err != nil {
+		t.Fatalf("Failed to parse Error: %s", err)
+	}
+
+	exp := Compound{
+		{Name: "Name", Tag: String("x")},
+		{Name: "Count", Tag: Byte(3)},
+		{Name: "Pos", Tag: List{Elem: TagDouble, Values: []Tag{Double(1), Double(2)}}},
+		{Name: "quoted key", Tag: IntArray{1, 2, 3}},
+		{Name: "big", Tag: Double(1500)},
+		{Name: "flag", Tag: Byte(1)},
+		{Name: "id", Tag: String("minecraft")},
+	}
+
+	if !reflect.DeepEqual(tag, exp) {
+		t.Fatalf("Expected %#v for tag, but %#v", exp, tag)
+	}
+
+	s := FormatSNBT(exp[:3], "")
+	if s != `{Name:"x",Count:3b,Pos:[1.0d,2.0d]}` {
+		t.Fatalf("Expected %s for snbt, but %s", `{Name:"x",Count:3b,Pos:[1.0d,2.0d]}`, s)
+	}
+}
+
+func TestFormatSNBTIndent(t *testing.T) {
+	tag := Compound{
+		{Name: "a b", Tag: List{Elem: TagShort, Values: []Tag{Short(1), Short(-2)}}},
+		{Name: "c", Tag: Compound{}},
+		{Name: "d", Tag: LongArray{1, 2}},
+	}
+
+	exp := "{\n  \"a b\": [\n    1s,\n    -2s\n  ],\n  c: {},\n  d: [L;1L, 2L]\n}"
+
+	s := FormatSNBT(tag, "  ")
+	if s != exp {
+		t.Fatalf("Expected %s for snbt, but %s", exp, s)
+	}
+}
+
+func TestSNBTRoundTrip(t *testing.T) {
+	tag := Compound{
+		{Name: "byte", Tag: Byte(-1)},
+		{Name: "short", Tag: Short(-300)},
+		{Name: "int", Tag: Int(-70000)},
+		{Name: "long", Tag: Long(-1 << 40)},
+		{Name: "float", Tag: Float(0.1)},
+		{Name: "double", Tag: Double(-2.25e-10)},
+		{Name: "bytes", Tag: ByteArray{0x00, 0xff}},
+		{Name: "string", Tag: String("\"あ\\\n'")},
+		{Name: "list", Tag: List{Elem: TagCompound, Values: []Tag{Compound{}, Compound{{Name: "", Tag: Byte(1)}}}}},
+		{Name: "lists", Tag: List{Elem: TagList, Values: []Tag{List{Elem: TagEnd, Values: []Tag{}}}}},
+		{Name: "ints", Tag: IntArray{}},
+		{Name: "longs", Tag: LongArray{1 << 50, -1}},
+	}
+
+	for _, indent := range []string{"", "\t"} {
+		got, err := ParseSNBT(FormatSNBT(tag, indent))
+		if err != nil {
+			t.Fatalf("Failed to parse Error: %s", err)
+		}
+
+		if !reflect.DeepEqual(got, tag) {
+			t.Fatalf("Expected %#v for tag, but %#v", tag, got)
+		}
+	}
+}
+
+func TestParseSNBTError(t *testing.T) {
+	tests := []struct {
+		s    string
+		line int
+		col  int
+	}{
+		{"{\n  a: 1,\n  b: [1, 2b]\n}", 3, 10},
+		{"{a: 1", 1, 6},
+		{"[B; 1, 128]", 1, 8},
+		{"{a: 128b}", 1, 5},
+		{"{a: \"x}", 1, 8},
+		{"[X; 1]", 1, 2},
+		{"1 2", 1, 3},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSNBT(test.s)
+
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Fatalf("%q: Expected a SyntaxError, but %v", test.s, err)
+		}
+
+		if serr.Line != test.line || serr.Column != test.col {
+			t.Fatalf("%q: Expected %d:%d for position, but %d:%d (%s)", test.s, test.line, test.col, serr.Line, serr.Column, serr.Msg)
+		}
+	}
+}
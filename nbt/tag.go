@@ -0,0 +1,134 @@
+package nbt
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+// Tag is a value of NBT
+type Tag interface {
+	Type() TagType
+}
+
+// Byte is TAG_Byte
+type Byte int8
+
+// Type returns TagByte
+func (Byte) Type() TagType { return TagByte }
+
+// Short is TAG_Short
+type Short int16
+
+// Type returns TagShort
+func (Short) Type() TagType { return TagShort }
+
+// Int is TAG_Int
+type Int int32
+
+// Type returns TagInt
+func (Int) Type() TagType { return TagInt }
+
+// Long is TAG_Long
+type Long int64
+
+// Type returns TagLong
+func (Long) Type() TagType { return TagLong }
+
+// Float is TAG_Float
+type Float float32
+
+// Type returns TagFloat
+func (Float) Type() TagType { return TagFloat }
+
+// Double is TAG_Double
+type Double float64
+
+// Type returns TagDouble
+func (Double) Type() TagType { return TagDouble }
+
+// ByteArray is TAG_Byte_Array
+type ByteArray []byte
+
+// Type returns TagByteArray
+func (ByteArray) Type() TagType { return TagByteArray }
+
+// String is TAG_String
+type String string
+
+// Type returns TagString
+func (String) Type() TagType { return TagString }
+
+// List is TAG_List
+// All values must be tags of Elem. An empty list may have TagEnd as Elem.
+type List struct {
+	Elem   TagType
+	Values []Tag
+}
+
+// Type returns TagList
+func (List) Type() TagType { return TagList }
+
+// Entry is a named tag of Compound
+type Entry struct {
+	Name string
+	Tag  Tag
+}
+
+// Compound is TAG_Compound
+// It keeps order of the entries.
+type Compound []Entry
+
+// Type returns TagCompound
+func (Compound) Type() TagType { return TagCompound }
+
+// Get returns the tag of the name, or nil if it doesn't exist
+func (c Compound) Get(name string) Tag {
+	for _, e := range c {
+		if e.Name == name {
+			return e.Tag
+		}
+	}
+
+	return nil
+}
+
+// Set sets the tag of the name
+// It replaces the existing tag, or appends a new entry
+func (c *Compound) Set(name string, tag Tag) {
+	for i, e := range *c {
+		if e.Name == name {
+			(*c)[i].Tag = tag
+
+			return
+		}
+	}
+
+	*c = append(*c, Entry{Name: name, Tag: tag})
+}
+
+// Delete deletes the tag of the name
+func (c *Compound) Delete(name string) {
+	for i, e := range *c {
+		if e.Name == name {
+			*c = append((*c)[:i], (*c)[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// IntArray is TAG_Int_Array
+type IntArray []int32
+
+// Type returns TagIntArray
+func (IntArray) Type() TagType { return TagIntArray }
+
+// LongArray is TAG_Long_Array
+type LongArray []int64
+
+// Type returns TagLongArray
+func (LongArray) Type() TagType { return TagLongArray }
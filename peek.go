@@ -0,0 +1,376 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+// Peek methods get values at the offset without moving it.
+// They return the error of the stream if a read failed, but they don't record a new error.
+
+// Peek gets n bytes without moving offset
+// The returned bytes share the buffer
+func (bs *Stream) Peek(n int) ([]byte, error) {
+	if !bs.correct {
+		return nil, bs.err
+	}
+
+	return bs.at("Bytes", bs.off, n)
+}
+
+// PeekByte gets an unsigned byte without moving offset
+func (bs *Stream) PeekByte() (byte, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.ByteAt(bs.off)
+}
+
+// PeekSByte gets a signed byte without moving offset
+func (bs *Stream) PeekSByte() (int8, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.SByteAt(bs.off)
+}
+
+// PeekShort gets an unsigned short without moving offset
+func (bs *Stream) PeekShort() (uint16, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.ShortAt(bs.off)
+}
+
+// PeekSShort gets a signed short without moving offset
+func (bs *Stream) PeekSShort() (int16, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.SShortAt(bs.off)
+}
+
+// PeekLShort gets an unsigned short with LittleEndian without moving offset
+func (bs *Stream) PeekLShort() (uint16, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LShortAt(bs.off)
+}
+
+// PeekLSShort gets a signed short with LittleEndian without moving offset
+func (bs *Stream) PeekLSShort() (int16, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LSShortAt(bs.off)
+}
+
+// PeekTriad gets a signed triad without moving offset
+func (bs *Stream) PeekTriad() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.TriadAt(bs.off)
+}
+
+// PeekUTriad gets an unsigned triad without moving offset
+func (bs *Stream) PeekUTriad() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.UTriadAt(bs.off)
+}
+
+// PeekLTriad gets a signed triad with LittleEndian without moving offset
+func (bs *Stream) PeekLTriad() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LTriadAt(bs.off)
+}
+
+// PeekLUTriad gets an unsigned triad with LittleEndian without moving offset
+func (bs *Stream) PeekLUTriad() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LUTriadAt(bs.off)
+}
+
+// PeekInt gets a signed int without moving offset
+func (bs *Stream) PeekInt() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.IntAt(bs.off)
+}
+
+// PeekUInt gets an unsigned int without moving offset
+func (bs *Stream) PeekUInt() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.UIntAt(bs.off)
+}
+
+// PeekLInt gets a signed int with LittleEndian without moving offset
+func (bs *Stream) PeekLInt() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LIntAt(bs.off)
+}
+
+// PeekLUInt gets an unsigned int with LittleEndian without moving offset
+func (bs *Stream) PeekLUInt() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LUIntAt(bs.off)
+}
+
+// PeekLong gets a signed long without moving offset
+func (bs *Stream) PeekLong() (int64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LongAt(bs.off)
+}
+
+// PeekLLong gets a signed long with LittleEndian without moving offset
+func (bs *Stream) PeekLLong() (int64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LLongAt(bs.off)
+}
+
+// PeekULong gets an unsigned long without moving offset
+func (bs *Stream) PeekULong() (uint64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.ULongAt(bs.off)
+}
+
+// PeekLULong gets an unsigned long with LittleEndian without moving offset
+func (bs *Stream) PeekLULong() (uint64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LULongAt(bs.off)
+}
+
+// PeekFloat gets a float without moving offset
+func (bs *Stream) PeekFloat() (float32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.FloatAt(bs.off)
+}
+
+// PeekLFloat gets a float with LittleEndian without moving offset
+func (bs *Stream) PeekLFloat() (float32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LFloatAt(bs.off)
+}
+
+// PeekDouble gets a double without moving offset
+func (bs *Stream) PeekDouble() (float64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.DoubleAt(bs.off)
+}
+
+// PeekLDouble gets a double with LittleEndian without moving offset
+func (bs *Stream) PeekLDouble() (float64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LDoubleAt(bs.off)
+}
+
+// PeekBool gets a byte as bool without moving offset
+func (bs *Stream) PeekBool() (bool, error) {
+	if !bs.correct {
+		return false, bs.err
+	}
+
+	return bs.BoolAt(bs.off)
+}
+
+// PeekVarInt gets a signed varint (ZigZag encoded) without moving offset
+func (bs *Stream) PeekVarInt() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, _, err := ReadEVarInt(bs.Bytes())
+	if err != nil {
+		return 0, &DecodeError{Off: bs.off, Type: "VarInt", Need: varIntNeed(err, bs.Len()), Avail: bs.Len(), Err: err}
+	}
+
+	return value, nil
+}
+
+// PeekUVarInt gets an unsigned varint without moving offset
+func (bs *Stream) PeekUVarInt() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, _, err := ReadEUVarInt(bs.Bytes())
+	if err != nil {
+		return 0, &DecodeError{Off: bs.off, Type: "UVarInt", Need: varIntNeed(err, bs.Len()), Avail: bs.Len(), Err: err}
+	}
+
+	return value, nil
+}
+
+// PeekVarLong gets a signed varlong (ZigZag encoded) without moving offset
+func (bs *Stream) PeekVarLong() (int64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, _, err := ReadEVarLong(bs.Bytes())
+	if err != nil {
+		return 0, &DecodeError{Off: bs.off, Type: "VarLong", Need: varIntNeed(err, bs.Len()), Avail: bs.Len(), Err: err}
+	}
+
+	return value, nil
+}
+
+// PeekUVarLong gets an unsigned varlong without moving offset
+func (bs *Stream) PeekUVarLong() (uint64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, _, err := ReadEUVarLong(bs.Bytes())
+	if err != nil {
+		return 0, &DecodeError{Off: bs.off, Type: "UVarLong", Need: varIntNeed(err, bs.Len()), Avail: bs.Len(), Err: err}
+	}
+
+	return value, nil
+}
+
+// PeekShort gets an unsigned short with the order without moving offset
+func (bs *OrderStream) PeekShort() (uint16, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.ShortAt(bs.off)
+}
+
+// PeekSShort gets a signed short with the order without moving offset
+func (bs *OrderStream) PeekSShort() (int16, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.SShortAt(bs.off)
+}
+
+// PeekTriad gets a signed triad with the order without moving offset
+func (bs *OrderStream) PeekTriad() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.TriadAt(bs.off)
+}
+
+// PeekUTriad gets an unsigned triad with the order without moving offset
+func (bs *OrderStream) PeekUTriad() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.UTriadAt(bs.off)
+}
+
+// PeekInt gets a signed int with the order without moving offset
+func (bs *OrderStream) PeekInt() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.IntAt(bs.off)
+}
+
+// PeekUInt gets an unsigned int with the order without moving offset
+func (bs *OrderStream) PeekUInt() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.UIntAt(bs.off)
+}
+
+// PeekLong gets a signed long with the order without moving offset
+func (bs *OrderStream) PeekLong() (int64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.LongAt(bs.off)
+}
+
+// PeekULong gets an unsigned long with the order without moving offset
+func (bs *OrderStream) PeekULong() (uint64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.ULongAt(bs.off)
+}
+
+// PeekFloat gets a float with the order without moving offset
+func (bs *OrderStream) PeekFloat() (float32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.FloatAt(bs.off)
+}
+
+// PeekDouble gets a double with the order without moving offset
+func (bs *OrderStream) PeekDouble() (float64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	return bs.DoubleAt(bs.off)
+}
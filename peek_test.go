@@ -0,0 +1,113 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStreamPeek(t *testing.T) {
+	stream := NewStreamBytes(Magic)
+	stream.Skip(2)
+
+	b, err := stream.Peek(3)
+	if err != nil {
+		t.Fatalf("Failed to peek bytes Error: %s", err)
+	}
+
+	if !bytes.Equal(b, Magic[2:5]) {
+		t.Fatalf("Expected % x for bytes, but % x", Magic[2:5], b)
+	}
+
+	val, _ := stream.PeekShort()
+	if val != 0x0203 {
+		t.Fatalf("Expected %x for short, but %x", 0x0203, val)
+	}
+
+	lval, _ := stream.PeekLUInt()
+	if lval != 0x05040302 {
+		t.Fatalf("Expected %x for int, but %x", 0x05040302, lval)
+	}
+
+	if stream.Off() != 2 {
+		t.Fatalf("Expected %d for offset, but %d", 2, stream.Off())
+	}
+
+	_, err = stream.Peek(MagicLen)
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+
+	_, err = stream.Peek(-1)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v for negative count, but %v", ErrInvalidOffset, err)
+	}
+
+	if stream.Err() != nil {
+		t.Fatalf("Expected no error, but %v", stream.Err())
+	}
+}
+
+func TestStreamPeekVarInt(t *testing.T) {
+	stream := NewStream()
+	stream.PutVarInt(-300)
+
+	val, err := stream.PeekVarInt()
+	if err != nil {
+		t.Fatalf("Failed to peek varint Error: %s", err)
+	}
+
+	if val != -300 {
+		t.Fatalf("Expected %d for varint, but %d", -300, val)
+	}
+
+	val, _ = stream.VarInt()
+	if val != -300 {
+		t.Fatalf("Expected %d for varint, but %d", -300, val)
+	}
+
+	_, err = stream.PeekVarInt()
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || !errors.Is(err, ErrNotEnought) || derr.Need != 1 {
+		t.Fatalf("Expected %v needing %d byte, but %v", ErrNotEnought, 1, err)
+	}
+}
+
+func TestStreamPeekErr(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x01})
+
+	stream.Int()
+
+	_, err := stream.PeekByte()
+	if err != stream.Err() {
+		t.Fatalf("Expected %v, but %v", stream.Err(), err)
+	}
+}
+
+func TestOrderStreamPeek(t *testing.T) {
+	stream := NewOrderStreamBytes(LittleEndian, []byte{0x01, 0x02, 0x03, 0x04})
+
+	val, _ := stream.PeekUInt()
+	if val != 0x04030201 {
+		t.Fatalf("Expected %x for int, but %x", 0x04030201, val)
+	}
+
+	sval, _ := stream.PeekShort()
+	if sval != 0x0201 {
+		t.Fatalf("Expected %x for short, but %x", 0x0201, sval)
+	}
+
+	if stream.Off() != 0 {
+		t.Fatalf("Expected %d for offset, but %d", 0, stream.Off())
+	}
+}
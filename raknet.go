@@ -0,0 +1,256 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+)
+
+// RakNet primitives
+
+var (
+	// ErrUnknownAddress is returned when an address isn't IPv4 or IPv6
+	ErrUnknownAddress = errors.New("binary: unknown address version")
+
+	// ErrInvalidAckRange is returned when an ACK range is invalid
+	ErrInvalidAckRange = errors.New("binary: invalid ack range")
+)
+
+// OfflineMagic is the magic of RakNet offline messages
+var OfflineMagic = []byte{0x00, 0xff, 0xff, 0x00, 0xfe, 0xfe, 0xfe, 0xfe, 0xfd, 0xfd, 0xfd, 0xfd, 0x12, 0x34, 0x56, 0x78}
+
+const (
+	// AddressV4Size is byte size of an IPv4 address with the version
+	AddressV4Size = 1 + net.IPv4len + ShortSize
+
+	// AddressV6Size is byte size of an IPv6 address with the version
+	AddressV6Size = 1 + ShortSize + ShortSize + IntSize + net.IPv6len + IntSize
+
+	// afInet6 is AF_INET6 of Windows, which is used for IPv6 addresses
+	afInet6 = 23
+
+	// MaxTriad is max value of an unsigned triad
+	MaxTriad = 0xffffff
+)
+
+// PutOfflineMagic puts the magic of offline messages
+func (bs *Stream) PutOfflineMagic() error {
+	return bs.Put(OfflineMagic)
+}
+
+// ExpectOfflineMagic gets the magic of offline messages, and returns an error if it doesn't match
+func (bs *Stream) ExpectOfflineMagic() error {
+	return bs.Expect(OfflineMagic)
+}
+
+// Address gets an address
+//
+// IPv4 is the version (4), inverted bytes of the address and the port.
+// IPv6 is the version (6) and sockaddr_in6 (family, port, flow info, address and scope id).
+func (bs *Stream) Address() (*net.UDPAddr, error) {
+	ver, err := bs.Byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch ver {
+	case 4:
+		b, err := bs.get("Address", net.IPv4len+ShortSize)
+		if err != nil {
+			return nil, err
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		for i := range ip {
+			ip[i] = ^b[i]
+		}
+
+		return &net.UDPAddr{
+			IP:   ip,
+			Port: int(ReadUShort(b[net.IPv4len:])),
+		}, nil
+	case 6:
+		b, err := bs.get("Address", AddressV6Size-1)
+		if err != nil {
+			return nil, err
+		}
+
+		// family (ShortSize) is ignored
+		addr := &net.UDPAddr{
+			IP:   make(net.IP, net.IPv6len),
+			Port: int(ReadUShort(b[2:])),
+		}
+
+		copy(addr.IP, b[8:8+net.IPv6len])
+
+		if scope := ReadUInt(b[8+net.IPv6len:]); scope != 0 {
+			addr.Zone = strconv.FormatUint(uint64(scope), 10)
+		}
+
+		return addr, nil
+	}
+
+	bs.off--
+
+	return nil, bs.fail("Address", 0, ErrUnknownAddress)
+}
+
+// PutAddress puts an address
+// The address is put as IPv4 if it's an IPv4 address (including IPv4-mapped IPv6 addresses)
+// The scope id of IPv6 is the zone if it's numeric, or 0.
+// It returns ErrUnknownAddress if addr is nil or the port is out of range.
+func (bs *Stream) PutAddress(addr *net.UDPAddr) error {
+	if addr == nil || addr.Port < 0 || addr.Port > 0xffff {
+		return ErrUnknownAddress
+	}
+
+	if ip := addr.IP.To4(); ip != nil {
+		bs.buf = append(bs.buf, 4)
+		for _, b := range ip {
+			bs.buf = append(bs.buf, ^b)
+		}
+
+		bs.buf = AppendUShort(bs.buf, uint16(addr.Port))
+
+		return nil
+	}
+
+	ip := addr.IP.To16()
+	if ip == nil {
+		return ErrUnknownAddress
+	}
+
+	// only numeric zones are put, interface names are put as 0 to not depend on the host
+	var scope uint32
+	if n, err := strconv.ParseUint(addr.Zone, 10, 32); err == nil {
+		scope = uint32(n)
+	}
+
+	bs.buf = append(bs.buf, 6)
+	bs.buf = AppendLUShort(bs.buf, afInet6)
+	bs.buf = AppendUShort(bs.buf, uint16(addr.Port))
+	bs.buf = AppendUInt(bs.buf, 0) // flow info
+	bs.buf = append(bs.buf, ip...)
+	bs.buf = AppendUInt(bs.buf, scope)
+
+	return nil
+}
+
+// AckRange is a range of sequence numbers in ACK and NACK
+type AckRange struct {
+	Start uint32
+	End   uint32 // inclusive
+}
+
+// NewAckRanges returns ranges of the sequence numbers
+// Consecutive numbers are compacted into a range. seqs isn't changed.
+func NewAckRanges(seqs []uint32) []AckRange {
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	sorted := make([]uint32, len(seqs))
+	copy(sorted, seqs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	ranges := []AckRange{{Start: sorted[0], End: sorted[0]}}
+	for _, seq := range sorted[1:] {
+		last := &ranges[len(ranges)-1]
+		if seq <= last.End+1 {
+			if seq > last.End {
+				last.End = seq
+			}
+
+			continue
+		}
+
+		ranges = append(ranges, AckRange{Start: seq, End: seq})
+	}
+
+	return ranges
+}
+
+// AckRanges gets ranges of ACK and NACK records
+//
+// Records are a count (Short), and a flag whether it's a single number (Byte) and the numbers (LUTriad).
+func (bs *Stream) AckRanges() ([]AckRange, error) {
+	n, err := bs.Short()
+	if err != nil {
+		return nil, err
+	}
+
+	// a record has 4 bytes at least
+	if int(n)*(ByteSize+TriadSize) > bs.Len() {
+		return nil, bs.fail("AckRanges", int(n)*(ByteSize+TriadSize), ErrNotEnought)
+	}
+
+	ranges := make([]AckRange, n)
+	for i := range ranges {
+		single, err := bs.Bool()
+		if err != nil {
+			return nil, err
+		}
+
+		start, err := bs.LUTriad()
+		if err != nil {
+			return nil, err
+		}
+
+		end := start
+		if !single {
+			end, err = bs.LUTriad()
+			if err != nil {
+				return nil, err
+			}
+
+			if start > end {
+				return nil, bs.fail("AckRanges", 0, ErrInvalidAckRange)
+			}
+		}
+
+		ranges[i] = AckRange{Start: start, End: end}
+	}
+
+	return ranges, nil
+}
+
+// PutAckRanges puts ranges as ACK and NACK records
+func (bs *Stream) PutAckRanges(ranges []AckRange) error {
+	if len(ranges) > 0xffff {
+		return ErrInvalidAckRange
+	}
+
+	for _, r := range ranges {
+		if r.Start > r.End || r.End > MaxTriad {
+			return ErrInvalidAckRange
+		}
+	}
+
+	bs.buf = AppendUShort(bs.buf, uint16(len(ranges)))
+	for _, r := range ranges {
+		if r.Start == r.End {
+			bs.buf = append(bs.buf, 1)
+			bs.buf = AppendLUTriad(bs.buf, r.Start)
+
+			continue
+		}
+
+		bs.buf = append(bs.buf, 0)
+		bs.buf = AppendLUTriad(bs.buf, r.Start)
+		bs.buf = AppendLUTriad(bs.buf, r.End)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// The fixtures aren't packet captures. They are written by hand from the layouts of RakLib (pmmp/RakLib)
+// and go-raknet (sandertv/go-raknet), not from this package, and they are decoded before encoded.
+
+// openConnectionReply2 is Open Connection Reply 2 to 192.168.1.1:19132
+// RakLib OpenConnectionReply2: id, magic, server guid (long), client address, mtu (short), security (bool)
+// IPv4 addresses are the version 4, the inverted bytes and the port (big endian)
+var openConnectionReply2 = []byte{
+	0x08,                                                                                           // id
+	0x00, 0xff, 0xff, 0x00, 0xfe, 0xfe, 0xfe, 0xfe, 0xfd, 0xfd, 0xfd, 0xfd, 0x12, 0x34, 0x56, 0x78, // magic
+	0x00, 0x00, 0x00, 0x00, 0x07, 0x5b, 0xcd, 0x15, // server guid
+	0x04, 0x3f, 0x57, 0xfe, 0xfe, 0x4a, 0xbc, // client address
+	0x05, 0xd4, // mtu
+	0x00, // security
+}
+
+func TestStreamAddressV4(t *testing.T) {
+	stream := NewStreamBytes(openConnectionReply2)
+	stream.Skip(1)
+
+	err := stream.ExpectOfflineMagic()
+	if err != nil {
+		t.Fatalf("Failed to expect magic Error: %s", err)
+	}
+
+	stream.Long()
+
+	addr, err := stream.Address()
+	if err != nil {
+		t.Fatalf("Failed to get an address Error: %s", err)
+	}
+
+	if addr.String() != "192.168.1.1:19132" {
+		t.Fatalf("Expected %s for address, but %s", "192.168.1.1:19132", addr)
+	}
+
+	mtu, _ := stream.Short()
+	if mtu != 1492 {
+		t.Fatalf("Expected %d for mtu, but %d", 1492, mtu)
+	}
+
+	out := NewStream()
+	out.PutByte(0x08)
+	out.PutOfflineMagic()
+	out.PutLong(123456789)
+	out.PutAddress(addr)
+	out.PutShort(1492)
+	out.PutBool(false)
+
+	if !bytes.Equal(out.AllBytes(), openConnectionReply2) {
+		t.Fatalf("Expected % x for bytes, but % x", openConnectionReply2, out.AllBytes())
+	}
+}
+
+func TestStreamAddressV6(t *testing.T) {
+	// sockaddr_in6 with the family of Windows (AF_INET6 = 23) in little endian, as go-raknet writes it
+	// The port, the flow info and the scope id are big endian.
+	data := []byte{
+		0x06,       // version
+		0x17, 0x00, // family
+		0x4a, 0xbc, // port
+		0x00, 0x00, 0x00, 0x00, // flow info
+		0xfe, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, // address
+		0x00, 0x00, 0x00, 0x02, // scope id
+	}
+
+	if len(data) != AddressV6Size {
+		t.Fatalf("Expected %d for len, but %d", AddressV6Size, len(data))
+	}
+
+	addr, err := NewStreamBytes(data).Address()
+	if err != nil {
+		t.Fatalf("Failed to get an address Error: %s", err)
+	}
+
+	if addr.String() != "[fe80::1%2]:19132" {
+		t.Fatalf("Expected %s for address, but %s", "[fe80::1%2]:19132", addr)
+	}
+
+	stream := NewStream()
+
+	err = stream.PutAddress(addr)
+	if err != nil {
+		t.Fatalf("Failed to put an address Error: %s", err)
+	}
+
+	if !bytes.Equal(stream.AllBytes(), data) {
+		t.Fatalf("Expected % x for bytes, but % x", data, stream.AllBytes())
+	}
+
+	// interface names aren't looked up
+	stream = NewStream()
+	stream.PutAddress(&net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 19132, Zone: "eth0"})
+
+	if scope, _ := stream.UIntAt(AddressV6Size - IntSize); scope != 0 {
+		t.Fatalf("Expected %d for scope id, but %d", 0, scope)
+	}
+
+	err = NewStream().PutAddress(nil)
+	if !errors.Is(err, ErrUnknownAddress) {
+		t.Fatalf("Expected %v for nil, but %v", ErrUnknownAddress, err)
+	}
+
+	_, err = NewStreamBytes([]byte{0x05}).Address()
+	if !errors.Is(err, ErrUnknownAddress) {
+		t.Fatalf("Expected %v, but %v", ErrUnknownAddress, err)
+	}
+}
+
+func TestStreamAckRanges(t *testing.T) {
+	// ACK of 1, 2, 3, 5, 7, 8
+	// RakLib AcknowledgePacket: id, count (short), and records of a single flag (bool) and the numbers (little endian triad)
+	data := []byte{
+		0xc0,       // id
+		0x00, 0x03, // count
+		0x00, 0x01, 0x00, 0x00, 0x03, 0x00, 0x00, // 1 - 3
+		0x01, 0x05, 0x00, 0x00, // 5
+		0x00, 0x07, 0x00, 0x00, 0x08, 0x00, 0x00, // 7 - 8
+	}
+
+	expRanges := []AckRange{{1, 3}, {5, 5}, {7, 8}}
+
+	stream := NewStreamBytes(data)
+	stream.Skip(1)
+
+	got, err := stream.AckRanges()
+	if err != nil {
+		t.Fatalf("Failed to get ranges Error: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expRanges) {
+		t.Fatalf("Expected %v for ranges, but %v", expRanges, got)
+	}
+
+	ranges := NewAckRanges([]uint32{8, 2, 1, 3, 5, 7, 2})
+	if !reflect.DeepEqual(ranges, expRanges) {
+		t.Fatalf("Expected %v for ranges, but %v", expRanges, ranges)
+	}
+
+	stream = NewStream()
+	stream.PutByte(0xc0)
+
+	err = stream.PutAckRanges(ranges)
+	if err != nil {
+		t.Fatalf("Failed to put ranges Error: %s", err)
+	}
+
+	if !bytes.Equal(stream.AllBytes(), data) {
+		t.Fatalf("Expected % x for bytes, but % x", data, stream.AllBytes())
+	}
+}
+
+func TestStreamAckRangesError(t *testing.T) {
+	err := NewStream().PutAckRanges([]AckRange{{Start: 2, End: 1}})
+	if !errors.Is(err, ErrInvalidAckRange) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidAckRange, err)
+	}
+
+	err = NewStream().PutAckRanges([]AckRange{{Start: 0, End: MaxTriad + 1}})
+	if !errors.Is(err, ErrInvalidAckRange) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidAckRange, err)
+	}
+
+	// a huge count without records
+	_, err = NewStreamBytes([]byte{0xff, 0xff, 0x01}).AckRanges()
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+
+	_, err = NewStreamBytes([]byte{0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x01, 0x00, 0x00}).AckRanges()
+	if !errors.Is(err, ErrInvalidAckRange) {
+		t.Fatalf("Expected %v, but %v", ErrInvalidAckRange, err)
+	}
+}
@@ -0,0 +1,510 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// DefaultBufferSize is the default buffer size of Reader and Writer
+const DefaultBufferSize = 4096
+
+// NewReader returns new Reader
+func NewReader(r io.Reader) *Reader {
+	return NewReaderSize(r, DefaultBufferSize)
+}
+
+// NewReaderSize returns new Reader with the buffer size
+func NewReaderSize(r io.Reader, size int) *Reader {
+	return &Reader{
+		r:      bufio.NewReaderSize(r, size),
+		maxLen: DefaultMaxLen,
+	}
+}
+
+// Reader is a binary reader with an io.Reader
+// It reads values like Stream, and returns io.ErrUnexpectedEOF if a value is cut off
+type Reader struct {
+	r      *bufio.Reader
+	b      [LongSize]byte
+	off    int64
+	maxLen int
+}
+
+// Off returns count of read bytes
+func (r *Reader) Off() int64 {
+	return r.off
+}
+
+// SetMaxLen sets max length of strings and byte arrays
+// If n is 0 or less, the length is not limited
+func (r *Reader) SetMaxLen(n int) {
+	r.maxLen = n
+}
+
+// MaxLen returns max length of strings and byte arrays
+func (r *Reader) MaxLen() int {
+	return r.maxLen
+}
+
+// Read reads up to len(p) bytes into p
+func (r *Reader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	r.off += int64(n)
+
+	return n, err
+}
+
+// full reads len(p) bytes into p
+// It returns io.EOF if no bytes were read, and io.ErrUnexpectedEOF if a part of p was read
+func (r *Reader) full(p []byte) error {
+	n, err := io.ReadFull(r.r, p)
+	r.off += int64(n)
+
+	return err
+}
+
+// rest reads len(p) bytes of the rest of a value into p
+func (r *Reader) rest(p []byte) error {
+	err := r.full(p)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// get reads n bytes
+// The returned bytes are valid until the next reading
+func (r *Reader) get(n int) ([]byte, error) {
+	b := r.b[:n]
+	if err := r.full(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Skip skips n bytes
+func (r *Reader) Skip(n int) error {
+	d, err := r.r.Discard(n)
+	r.off += int64(d)
+	if err == io.EOF && d > 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// Byte gets an unsigned byte
+func (r *Reader) Byte() (byte, error) {
+	b, err := r.get(ByteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadByte(b), nil
+}
+
+// SByte gets a signed byte
+func (r *Reader) SByte() (int8, error) {
+	b, err := r.get(ByteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadSByte(b), nil
+}
+
+// Short gets an unsigned short
+func (r *Reader) Short() (uint16, error) {
+	b, err := r.get(ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUShort(b), nil
+}
+
+// SShort gets a signed short
+func (r *Reader) SShort() (int16, error) {
+	b, err := r.get(ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadShort(b), nil
+}
+
+// LShort gets an unsigned short with LittleEndian
+func (r *Reader) LShort() (uint16, error) {
+	b, err := r.get(ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUShort(b), nil
+}
+
+// LSShort gets a signed short with LittleEndian
+func (r *Reader) LSShort() (int16, error) {
+	b, err := r.get(ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLShort(b), nil
+}
+
+// Triad gets a signed triad
+func (r *Reader) Triad() (int32, error) {
+	b, err := r.get(TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadTriad(b), nil
+}
+
+// UTriad gets an unsigned triad
+func (r *Reader) UTriad() (uint32, error) {
+	b, err := r.get(TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUTriad(b), nil
+}
+
+// LTriad gets a signed triad with LittleEndian
+func (r *Reader) LTriad() (int32, error) {
+	b, err := r.get(TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLTriad(b), nil
+}
+
+// LUTriad gets an unsigned triad with LittleEndian
+func (r *Reader) LUTriad() (uint32, error) {
+	b, err := r.get(TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUTriad(b), nil
+}
+
+// Int gets a signed int
+func (r *Reader) Int() (int32, error) {
+	b, err := r.get(IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadInt(b), nil
+}
+
+// UInt gets an unsigned int
+func (r *Reader) UInt() (uint32, error) {
+	b, err := r.get(IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUInt(b), nil
+}
+
+// LInt gets a signed int with LittleEndian
+func (r *Reader) LInt() (int32, error) {
+	b, err := r.get(IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLInt(b), nil
+}
+
+// LUInt gets an unsigned int with LittleEndian
+func (r *Reader) LUInt() (uint32, error) {
+	b, err := r.get(IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUInt(b), nil
+}
+
+// Long gets a signed long
+func (r *Reader) Long() (int64, error) {
+	b, err := r.get(LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLong(b), nil
+}
+
+// LLong gets a signed long with LittleEndian
+func (r *Reader) LLong() (int64, error) {
+	b, err := r.get(LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLLong(b), nil
+}
+
+// ULong gets an unsigned long
+func (r *Reader) ULong() (uint64, error) {
+	b, err := r.get(LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadULong(b), nil
+}
+
+// LULong gets an unsigned long with LittleEndian
+func (r *Reader) LULong() (uint64, error) {
+	b, err := r.get(LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLULong(b), nil
+}
+
+// Float gets a float
+func (r *Reader) Float() (float32, error) {
+	b, err := r.get(FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadFloat(b), nil
+}
+
+// LFloat gets a float with LittleEndian
+func (r *Reader) LFloat() (float32, error) {
+	b, err := r.get(FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLFloat(b), nil
+}
+
+// Double gets a double
+func (r *Reader) Double() (float64, error) {
+	b, err := r.get(DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadDouble(b), nil
+}
+
+// LDouble gets a double with LittleEndian
+func (r *Reader) LDouble() (float64, error) {
+	b, err := r.get(DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLDouble(b), nil
+}
+
+// Bool gets a byte and returns as bool
+func (r *Reader) Bool() (bool, error) {
+	val, err := r.Byte()
+	if err != nil {
+		return false, err
+	}
+
+	return val != 0, nil
+}
+
+// uvarint reads an unsigned varint of max bytes
+// last is the max value of the last byte
+func (r *Reader) uvarint(max int, last byte) (uint64, error) {
+	var value uint64
+	for i := 0; i < max; i++ {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return 0, err
+		}
+
+		r.off++
+
+		if i == max-1 && b > last {
+			return 0, ErrVarIntOverflow
+		}
+
+		value |= uint64(b&0x7f) << (7 * uint(i))
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+
+	return 0, ErrVarIntOverflow
+}
+
+// VarInt gets a signed varint (ZigZag encoded)
+func (r *Reader) VarInt() (int32, error) {
+	value, err := r.UVarInt()
+	if err != nil {
+		return 0, err
+	}
+
+	return DecodeZigZag32(value), nil
+}
+
+// UVarInt gets an unsigned varint
+func (r *Reader) UVarInt() (uint32, error) {
+	value, err := r.uvarint(MaxVarIntSize, 0x0f)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(value), nil
+}
+
+// VarLong gets a signed varlong (ZigZag encoded)
+func (r *Reader) VarLong() (int64, error) {
+	value, err := r.UVarLong()
+	if err != nil {
+		return 0, err
+	}
+
+	return DecodeZigZag64(value), nil
+}
+
+// UVarLong gets an unsigned varlong
+func (r *Reader) UVarLong() (uint64, error) {
+	return r.uvarint(MaxVarLongSize, 0x01)
+}
+
+// Length gets a length with the prefix
+func (r *Reader) Length(prefix Prefix) (int, error) {
+	var ln uint64
+	var err error
+	switch prefix {
+	case PrefixByte:
+		var v byte
+		v, err = r.Byte()
+		ln = uint64(v)
+	case PrefixShort:
+		var v uint16
+		v, err = r.Short()
+		ln = uint64(v)
+	case PrefixLShort:
+		var v uint16
+		v, err = r.LShort()
+		ln = uint64(v)
+	case PrefixInt:
+		var v uint32
+		v, err = r.UInt()
+		ln = uint64(v)
+	case PrefixLInt:
+		var v uint32
+		v, err = r.LUInt()
+		ln = uint64(v)
+	case PrefixVarInt:
+		var v uint32
+		v, err = r.UVarInt()
+		ln = uint64(v)
+	default:
+		return 0, ErrUnknownPrefix
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if ln > uint64(prefix.Max()) || (r.maxLen > 0 && ln > uint64(r.maxLen)) {
+		return 0, ErrTooLong
+	}
+
+	return int(ln), nil
+}
+
+// ByteArray gets bytes with a length prefix
+func (r *Reader) ByteArray(prefix Prefix) ([]byte, error) {
+	ln, err := r.Length(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, ln)
+	if err := r.rest(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// String gets a string with a length prefix
+func (r *Reader) String(prefix Prefix) (string, error) {
+	b, err := r.ByteArray(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// CString gets a null terminated string
+func (r *Reader) CString() (string, error) {
+	var b []byte
+	for {
+		c, err := r.r.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(b) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return "", err
+		}
+
+		r.off++
+
+		if c == 0x00 {
+			return string(b), nil
+		}
+
+		if r.maxLen > 0 && len(b) >= r.maxLen {
+			return "", ErrTooLong
+		}
+
+		b = append(b, c)
+	}
+}
+
+// FixedString gets a string of n bytes and trims trailing padding (0x00)
+func (r *Reader) FixedString(n int) (string, error) {
+	if n < 0 {
+		return "", &DecodeError{Off: int(r.off), Type: "FixedString", Need: n, Err: ErrInvalidOffset}
+	}
+
+	b := make([]byte, n)
+	if err := r.full(b); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimRight(b, "\x00")), nil
+}
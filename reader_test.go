@@ -0,0 +1,105 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReader(t *testing.T) {
+	stream := NewStream()
+	stream.PutInt(-1)
+	stream.PutLFloat(1.5)
+	stream.PutVarLong(-300)
+	stream.PutString(PrefixShort, "abc")
+	stream.PutLUTriad(0x010203)
+
+	// refills with each byte
+	reader := NewReaderSize(iotest.OneByteReader(bytes.NewReader(stream.Bytes())), 16)
+
+	i, err := reader.Int()
+	if err != nil || i != -1 {
+		t.Fatalf("Expected %d for int, but %d Error: %v", -1, i, err)
+	}
+
+	f, err := reader.LFloat()
+	if err != nil || f != 1.5 {
+		t.Fatalf("Expected %f for float, but %f Error: %v", 1.5, f, err)
+	}
+
+	l, err := reader.VarLong()
+	if err != nil || l != -300 {
+		t.Fatalf("Expected %d for varlong, but %d Error: %v", -300, l, err)
+	}
+
+	s, err := reader.String(PrefixShort)
+	if err != nil || s != "abc" {
+		t.Fatalf("Expected %s for string, but %s Error: %v", "abc", s, err)
+	}
+
+	tr, err := reader.LUTriad()
+	if err != nil || tr != 0x010203 {
+		t.Fatalf("Expected %d for triad, but %d Error: %v", 0x010203, tr, err)
+	}
+
+	if off := reader.Off(); off != int64(stream.Len()) {
+		t.Fatalf("Expected %d for offset, but %d", stream.Len(), off)
+	}
+
+	if _, err := reader.Byte(); err != io.EOF {
+		t.Fatalf("Expected %v for end, but %v", io.EOF, err)
+	}
+}
+
+func TestReaderUnexpectedEOF(t *testing.T) {
+	reader := NewReader(bytes.NewReader([]byte{0x00, 0x01}))
+	if _, err := reader.Int(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected %v for short int, but %v", io.ErrUnexpectedEOF, err)
+	}
+
+	reader = NewReader(bytes.NewReader([]byte{0x80}))
+	if _, err := reader.UVarInt(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected %v for short varint, but %v", io.ErrUnexpectedEOF, err)
+	}
+
+	reader = NewReader(bytes.NewReader([]byte{0x03, 'a'}))
+	if _, err := reader.String(PrefixByte); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected %v for short string, but %v", io.ErrUnexpectedEOF, err)
+	}
+
+	reader = NewReader(bytes.NewReader([]byte{0x03}))
+	if _, err := reader.String(PrefixByte); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected %v for short string, but %v", io.ErrUnexpectedEOF, err)
+	}
+
+	// hostile prefix
+	reader = NewReader(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+	if _, err := reader.ByteArray(PrefixInt); err != ErrTooLong {
+		t.Fatalf("Expected %v for too long bytes, but %v", ErrTooLong, err)
+	}
+}
+
+func TestReaderFixedStringNegative(t *testing.T) {
+	r := NewReader(bytes.NewReader([]byte{'a', 0x00}))
+
+	_, err := r.FixedString(-1)
+	if !errors.Is(err, ErrInvalidOffset) {
+		t.Fatalf("Expected %v for negative size, but %v", ErrInvalidOffset, err)
+	}
+
+	value, err := r.FixedString(2)
+	if err != nil || value != "a" {
+		t.Fatalf("Expected %s for string, but %s Error: %v", "a", value, err)
+	}
+}
@@ -0,0 +1,403 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	stdbinary "encoding/binary"
+	"math"
+)
+
+// BigEndian and LittleEndian implement encoding/binary.ByteOrder and encoding/binary.AppendByteOrder
+var (
+	_ stdbinary.ByteOrder       = BigEndian
+	_ stdbinary.AppendByteOrder = BigEndian
+	_ stdbinary.ByteOrder       = LittleEndian
+	_ stdbinary.AppendByteOrder = LittleEndian
+)
+
+// NativeEndian is the byte order of the host
+var NativeEndian = nativeEndian()
+
+func nativeEndian() Order {
+	var b [ShortSize]byte
+	stdbinary.NativeEndian.PutUint16(b[:], 1)
+	if b[0] == 1 {
+		return LittleEndian
+	}
+
+	return BigEndian
+}
+
+func (bigEndian) Uint16(b []byte) uint16 {
+	return stdbinary.BigEndian.Uint16(b)
+}
+
+func (bigEndian) Uint32(b []byte) uint32 {
+	return stdbinary.BigEndian.Uint32(b)
+}
+
+func (bigEndian) Uint64(b []byte) uint64 {
+	return stdbinary.BigEndian.Uint64(b)
+}
+
+func (bigEndian) PutUint16(b []byte, v uint16) {
+	stdbinary.BigEndian.PutUint16(b, v)
+}
+
+func (bigEndian) PutUint32(b []byte, v uint32) {
+	stdbinary.BigEndian.PutUint32(b, v)
+}
+
+func (bigEndian) PutUint64(b []byte, v uint64) {
+	stdbinary.BigEndian.PutUint64(b, v)
+}
+
+func (bigEndian) AppendUint16(b []byte, v uint16) []byte {
+	return AppendUShort(b, v)
+}
+
+func (bigEndian) AppendUint32(b []byte, v uint32) []byte {
+	return AppendUInt(b, v)
+}
+
+func (bigEndian) AppendUint64(b []byte, v uint64) []byte {
+	return AppendULong(b, v)
+}
+
+func (bigEndian) String() string {
+	return "BigEndian"
+}
+
+func (littleEndian) Uint16(b []byte) uint16 {
+	return stdbinary.LittleEndian.Uint16(b)
+}
+
+func (littleEndian) Uint32(b []byte) uint32 {
+	return stdbinary.LittleEndian.Uint32(b)
+}
+
+func (littleEndian) Uint64(b []byte) uint64 {
+	return stdbinary.LittleEndian.Uint64(b)
+}
+
+func (littleEndian) PutUint16(b []byte, v uint16) {
+	stdbinary.LittleEndian.PutUint16(b, v)
+}
+
+func (littleEndian) PutUint32(b []byte, v uint32) {
+	stdbinary.LittleEndian.PutUint32(b, v)
+}
+
+func (littleEndian) PutUint64(b []byte, v uint64) {
+	stdbinary.LittleEndian.PutUint64(b, v)
+}
+
+func (littleEndian) AppendUint16(b []byte, v uint16) []byte {
+	return AppendLUShort(b, v)
+}
+
+func (littleEndian) AppendUint32(b []byte, v uint32) []byte {
+	return AppendLUInt(b, v)
+}
+
+func (littleEndian) AppendUint64(b []byte, v uint64) []byte {
+	return AppendLULong(b, v)
+}
+
+func (littleEndian) String() string {
+	return "LittleEndian"
+}
+
+// ByteOrder is an Order which is also an encoding/binary.ByteOrder and encoding/binary.AppendByteOrder
+type ByteOrder interface {
+	Order
+	stdbinary.ByteOrder
+	stdbinary.AppendByteOrder
+}
+
+// ToByteOrder returns o as ByteOrder
+// BigEndian and LittleEndian are returned as they are
+func ToByteOrder(o Order) ByteOrder {
+	if bo, ok := o.(ByteOrder); ok {
+		return bo
+	}
+
+	return &orderAdapter{Order: o}
+}
+
+// orderAdapter adds encoding/binary methods to an Order
+type orderAdapter struct {
+	Order
+}
+
+func (o *orderAdapter) Uint16(b []byte) uint16 {
+	_ = b[ShortSize-1] // panics like encoding/binary
+	return o.UShort(b)
+}
+
+func (o *orderAdapter) Uint32(b []byte) uint32 {
+	_ = b[IntSize-1]
+	return o.UInt(b)
+}
+
+func (o *orderAdapter) Uint64(b []byte) uint64 {
+	_ = b[LongSize-1]
+	return o.ULong(b)
+}
+
+func (o *orderAdapter) PutUint16(b []byte, v uint16) {
+	_ = b[ShortSize-1]
+	o.AppendUShort(b[:0], v)
+}
+
+func (o *orderAdapter) PutUint32(b []byte, v uint32) {
+	_ = b[IntSize-1]
+	o.AppendUInt(b[:0], v)
+}
+
+func (o *orderAdapter) PutUint64(b []byte, v uint64) {
+	_ = b[LongSize-1]
+	o.AppendULong(b[:0], v)
+}
+
+func (o *orderAdapter) AppendUint16(b []byte, v uint16) []byte {
+	return o.AppendUShort(b, v)
+}
+
+func (o *orderAdapter) AppendUint32(b []byte, v uint32) []byte {
+	return o.AppendUInt(b, v)
+}
+
+func (o *orderAdapter) AppendUint64(b []byte, v uint64) []byte {
+	return o.AppendULong(b, v)
+}
+
+func (o *orderAdapter) String() string {
+	if s, ok := o.Order.(interface{ String() string }); ok {
+		return s.String()
+	}
+
+	return "Order"
+}
+
+// FromByteOrder returns an Order which uses bo
+// encoding/binary.BigEndian and LittleEndian are returned as BigEndian and LittleEndian of this package.
+// If bo implements encoding/binary.AppendByteOrder, Append methods use it.
+func FromByteOrder(bo stdbinary.ByteOrder) Order {
+	if o, ok := bo.(Order); ok {
+		return o
+	}
+
+	switch bo {
+	case stdbinary.BigEndian:
+		return BigEndian
+	case stdbinary.LittleEndian:
+		return LittleEndian
+	}
+
+	var b [ShortSize]byte
+	bo.PutUint16(b[:], 0x0102)
+
+	abo, _ := bo.(stdbinary.AppendByteOrder)
+
+	return &byteOrderAdapter{
+		bo:     bo,
+		abo:    abo,
+		little: b[0] == 0x02,
+	}
+}
+
+// byteOrderAdapter is an Order with an encoding/binary.ByteOrder
+type byteOrderAdapter struct {
+	bo     stdbinary.ByteOrder
+	abo    stdbinary.AppendByteOrder // nil if bo doesn't implement it
+	little bool                      // used for triads, which encoding/binary doesn't have
+}
+
+func (o *byteOrderAdapter) String() string {
+	return o.bo.String()
+}
+
+func (o *byteOrderAdapter) Byte(v []byte) byte {
+	return ReadByte(v)
+}
+
+func (o *byteOrderAdapter) SByte(v []byte) int8 {
+	return ReadSByte(v)
+}
+
+func (o *byteOrderAdapter) Short(v []byte) int16 {
+	return int16(o.bo.Uint16(v))
+}
+
+func (o *byteOrderAdapter) UShort(v []byte) uint16 {
+	return o.bo.Uint16(v)
+}
+
+func (o *byteOrderAdapter) Triad(v []byte) int32 {
+	if o.little {
+		return ReadLTriad(v)
+	}
+
+	return ReadTriad(v)
+}
+
+func (o *byteOrderAdapter) UTriad(v []byte) uint32 {
+	if o.little {
+		return ReadLUTriad(v)
+	}
+
+	return ReadUTriad(v)
+}
+
+func (o *byteOrderAdapter) Int(v []byte) int32 {
+	return int32(o.bo.Uint32(v))
+}
+
+func (o *byteOrderAdapter) UInt(v []byte) uint32 {
+	return o.bo.Uint32(v)
+}
+
+func (o *byteOrderAdapter) Long(v []byte) int64 {
+	return int64(o.bo.Uint64(v))
+}
+
+func (o *byteOrderAdapter) ULong(v []byte) uint64 {
+	return o.bo.Uint64(v)
+}
+
+func (o *byteOrderAdapter) Float(v []byte) float32 {
+	return math.Float32frombits(o.bo.Uint32(v))
+}
+
+func (o *byteOrderAdapter) Double(v []byte) float64 {
+	return math.Float64frombits(o.bo.Uint64(v))
+}
+
+func (o *byteOrderAdapter) PutByte(v byte) []byte {
+	return WriteByte(v)
+}
+
+func (o *byteOrderAdapter) PutSByte(v int8) []byte {
+	return WriteSByte(v)
+}
+
+func (o *byteOrderAdapter) PutShort(v int16) []byte {
+	return o.AppendShort(make([]byte, 0, ShortSize), v)
+}
+
+func (o *byteOrderAdapter) PutUShort(v uint16) []byte {
+	return o.AppendUShort(make([]byte, 0, ShortSize), v)
+}
+
+func (o *byteOrderAdapter) PutTriad(v int32) []byte {
+	return o.AppendTriad(make([]byte, 0, TriadSize), v)
+}
+
+func (o *byteOrderAdapter) PutUTriad(v uint32) []byte {
+	return o.AppendUTriad(make([]byte, 0, TriadSize), v)
+}
+
+func (o *byteOrderAdapter) PutInt(v int32) []byte {
+	return o.AppendInt(make([]byte, 0, IntSize), v)
+}
+
+func (o *byteOrderAdapter) PutUInt(v uint32) []byte {
+	return o.AppendUInt(make([]byte, 0, IntSize), v)
+}
+
+func (o *byteOrderAdapter) PutLong(v int64) []byte {
+	return o.AppendLong(make([]byte, 0, LongSize), v)
+}
+
+func (o *byteOrderAdapter) PutULong(v uint64) []byte {
+	return o.AppendULong(make([]byte, 0, LongSize), v)
+}
+
+func (o *byteOrderAdapter) PutFloat(v float32) []byte {
+	return o.AppendFloat(make([]byte, 0, FloatSize), v)
+}
+
+func (o *byteOrderAdapter) PutDouble(v float64) []byte {
+	return o.AppendDouble(make([]byte, 0, DoubleSize), v)
+}
+
+func (o *byteOrderAdapter) AppendByte(dst []byte, v byte) []byte {
+	return AppendByte(dst, v)
+}
+
+func (o *byteOrderAdapter) AppendSByte(dst []byte, v int8) []byte {
+	return AppendSByte(dst, v)
+}
+
+func (o *byteOrderAdapter) AppendShort(dst []byte, v int16) []byte {
+	return o.AppendUShort(dst, uint16(v))
+}
+
+func (o *byteOrderAdapter) AppendUShort(dst []byte, v uint16) []byte {
+	if o.abo != nil {
+		return o.abo.AppendUint16(dst, v)
+	}
+
+	var b [ShortSize]byte
+	o.bo.PutUint16(b[:], v)
+
+	return append(dst, b[:]...)
+}
+
+func (o *byteOrderAdapter) AppendTriad(dst []byte, v int32) []byte {
+	return o.AppendUTriad(dst, uint32(v))
+}
+
+func (o *byteOrderAdapter) AppendUTriad(dst []byte, v uint32) []byte {
+	if o.little {
+		return AppendLUTriad(dst, v)
+	}
+
+	return AppendUTriad(dst, v)
+}
+
+func (o *byteOrderAdapter) AppendInt(dst []byte, v int32) []byte {
+	return o.AppendUInt(dst, uint32(v))
+}
+
+func (o *byteOrderAdapter) AppendUInt(dst []byte, v uint32) []byte {
+	if o.abo != nil {
+		return o.abo.AppendUint32(dst, v)
+	}
+
+	var b [IntSize]byte
+	o.bo.PutUint32(b[:], v)
+
+	return append(dst, b[:]...)
+}
+
+func (o *byteOrderAdapter) AppendLong(dst []byte, v int64) []byte {
+	return o.AppendULong(dst, uint64(v))
+}
+
+func (o *byteOrderAdapter) AppendULong(dst []byte, v uint64) []byte {
+	if o.abo != nil {
+		return o.abo.AppendUint64(dst, v)
+	}
+
+	var b [LongSize]byte
+	o.bo.PutUint64(b[:], v)
+
+	return append(dst, b[:]...)
+}
+
+func (o *byteOrderAdapter) AppendFloat(dst []byte, v float32) []byte {
+	return o.AppendUInt(dst, math.Float32bits(v))
+}
+
+func (o *byteOrderAdapter) AppendDouble(dst []byte, v float64) []byte {
+	return o.AppendULong(dst, math.Float64bits(v))
+}
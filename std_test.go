@@ -0,0 +1,126 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	stdbinary "encoding/binary"
+	"testing"
+)
+
+// plainOrder is an encoding/binary.ByteOrder without AppendByteOrder
+type plainOrder struct {
+	stdbinary.ByteOrder
+}
+
+func TestFromByteOrder(t *testing.T) {
+	if o := FromByteOrder(stdbinary.BigEndian); o != BigEndian {
+		t.Fatalf("Expected BigEndian for order, but %v", o)
+	}
+
+	if o := FromByteOrder(stdbinary.LittleEndian); o != LittleEndian {
+		t.Fatalf("Expected LittleEndian for order, but %v", o)
+	}
+
+	if o := FromByteOrder(BigEndian); o != BigEndian {
+		t.Fatalf("Expected BigEndian for order, but %v", o)
+	}
+
+	for _, test := range []struct {
+		bo  stdbinary.ByteOrder
+		exp Order
+	}{
+		{plainOrder{stdbinary.BigEndian}, BigEndian},
+		{plainOrder{stdbinary.LittleEndian}, LittleEndian},
+	} {
+		o := FromByteOrder(test.bo)
+
+		stream := NewOrderStream(o)
+		stream.PutSShort(-2)
+		stream.PutTriad(-3)
+		stream.PutInt(-4)
+		stream.PutULong(0x0102030405060708)
+		stream.PutDouble(1.5)
+
+		exp := NewOrderStream(test.exp)
+		exp.PutSShort(-2)
+		exp.PutTriad(-3)
+		exp.PutInt(-4)
+		exp.PutULong(0x0102030405060708)
+		exp.PutDouble(1.5)
+
+		if !bytes.Equal(stream.Bytes(), exp.Bytes()) {
+			t.Fatalf("Expected % x for bytes, but % x", exp.Bytes(), stream.Bytes())
+		}
+
+		if v, _ := stream.SShort(); v != -2 {
+			t.Fatalf("Expected %d for short, but %d", -2, v)
+		}
+
+		if v, _ := stream.Triad(); v != -3 {
+			t.Fatalf("Expected %d for triad, but %d", -3, v)
+		}
+
+		if v, _ := stream.Int(); v != -4 {
+			t.Fatalf("Expected %d for int, but %d", -4, v)
+		}
+
+		if v, _ := stream.ULong(); v != 0x0102030405060708 {
+			t.Fatalf("Expected %x for long, but %x", uint64(0x0102030405060708), v)
+		}
+
+		if v, _ := stream.Double(); v != 1.5 {
+			t.Fatalf("Expected %f for double, but %f", 1.5, v)
+		}
+	}
+}
+
+func TestToByteOrder(t *testing.T) {
+	if bo := ToByteOrder(LittleEndian); bo != LittleEndian {
+		t.Fatalf("Expected LittleEndian for order, but %v", bo)
+	}
+
+	bo := ToByteOrder(FromByteOrder(plainOrder{stdbinary.LittleEndian}))
+
+	b := make([]byte, 8)
+	bo.PutUint32(b, 0x01020304)
+	if !bytes.Equal(b[:4], []byte{0x04, 0x03, 0x02, 0x01}) {
+		t.Fatalf("Expected % x for bytes, but % x", []byte{0x04, 0x03, 0x02, 0x01}, b[:4])
+	}
+
+	if v := bo.Uint32(b); v != 0x01020304 {
+		t.Fatalf("Expected %x for uint32, but %x", 0x01020304, v)
+	}
+
+	b = bo.AppendUint16(nil, 0x0102)
+	if !bytes.Equal(b, []byte{0x02, 0x01}) {
+		t.Fatalf("Expected % x for bytes, but % x", []byte{0x02, 0x01}, b)
+	}
+
+	// Use BigEndian with encoding/binary
+	var v uint32
+	err := stdbinary.Read(bytes.NewReader([]byte{0x01, 0x02, 0x03, 0x04}), ToByteOrder(BigEndian), &v)
+	if err != nil {
+		t.Fatalf("Failed to read Error: %s", err)
+	}
+
+	if v != 0x01020304 {
+		t.Fatalf("Expected %x for uint32, but %x", 0x01020304, v)
+	}
+}
+
+func TestNativeEndian(t *testing.T) {
+	var b [ShortSize]byte
+	stdbinary.NativeEndian.PutUint16(b[:], 0x0102)
+
+	if !bytes.Equal(NativeEndian.PutUShort(0x0102), b[:]) {
+		t.Fatalf("Expected % x for bytes, but % x", b[:], NativeEndian.PutUShort(0x0102))
+	}
+}
@@ -11,9 +11,20 @@ package binary
 
 import (
 	"errors"
+	"io"
+	"unicode/utf8"
 )
 
-var errNoEnough = errors.New("no enough buffer")
+var (
+	// ErrInvalidOffset is returned when an offset is out of the stream
+	ErrInvalidOffset = errors.New("binary: invalid offset")
+
+	// ErrInvalidWhence is returned when Seek gets an unknown whence
+	ErrInvalidWhence = errors.New("binary: invalid whence")
+
+	// ErrUnreadByte is returned when UnreadByte is called at the beginning
+	ErrUnreadByte = errors.New("binary: UnreadByte at the beginning of the stream")
+)
 
 // NewStream returns new Stream
 func NewStream() *Stream {
@@ -25,21 +36,84 @@ func NewStreamBytes(b []byte) *Stream {
 	return &Stream{
 		buf:     b,
 		correct: true,
+		maxLen:  DefaultMaxLen,
 	}
 }
 
 // Stream is basic binary stream.
+//
+// Once a read fails, the stream records the error and later reads return it without reading.
+// So you can read some values and check Err at the end.
 type Stream struct {
 	buf     []byte
 	off     int
 	correct bool
+	err     error
+	errOff  int
+	maxLen  int
+	lengths []lengthMark
+	ended   []endedMark // marks ended by EndLength, used by Rewind
 }
 
 // Reset resets Buffer
 func (bs *Stream) Reset() {
 	bs.correct = true
+	bs.err = nil
+	bs.errOff = 0
 	bs.off = 0
 	bs.buf = []byte{}
+	bs.lengths = nil
+	bs.ended = nil
+}
+
+// Err returns the first error which occurred reading
+func (bs *Stream) Err() error {
+	return bs.err
+}
+
+// ErrOff returns offset where the first error occurred
+func (bs *Stream) ErrOff() int {
+	return bs.errOff
+}
+
+// fail records a DecodeError as the first error, and returns the first error
+func (bs *Stream) fail(typ string, need int, err error) error {
+	if bs.correct {
+		bs.correct = false
+		bs.err = &DecodeError{
+			Off:   bs.off,
+			Type:  typ,
+			Need:  need,
+			Avail: bs.Len(),
+			Err:   err,
+		}
+		bs.errOff = bs.off
+	}
+
+	return bs.err
+}
+
+// Fail records a DecodeError of the type as the first error, and returns the first error
+// It's used by decoders outside of the package such as generated code.
+func (bs *Stream) Fail(typ string, need int, err error) error {
+	return bs.fail(typ, need, err)
+}
+
+// get gets n bytes of the type, or returns an error if the buffer doesn't have n bytes
+func (bs *Stream) get(typ string, n int) ([]byte, error) {
+	if !bs.correct {
+		return nil, bs.err
+	}
+
+	if n < 0 {
+		return nil, bs.fail(typ, n, ErrInvalidOffset)
+	}
+
+	if n > bs.Len() {
+		return nil, bs.fail(typ, n, ErrNotEnought)
+	}
+
+	return bs.Get(n), nil
 }
 
 // Off returns offset
@@ -48,7 +122,12 @@ func (bs *Stream) Off() int {
 }
 
 // Get gets n bytes from the buffer
+// It gets no bytes after a read failed
 func (bs *Stream) Get(n int) []byte {
+	if !bs.correct {
+		return []byte{}
+	}
+
 	off := bs.off
 	if n > bs.Len() {
 		n = bs.Len()
@@ -90,6 +169,10 @@ func (bs *Stream) Len() int {
 
 // Skip skips n bytes on buffer
 func (bs *Stream) Skip(n int) {
+	if !bs.correct {
+		return
+	}
+
 	if n > bs.Len() {
 		n = bs.Len()
 	}
@@ -97,13 +180,44 @@ func (bs *Stream) Skip(n int) {
 	bs.off += n
 }
 
+// Sub returns a stream of the next n bytes, and skips them
+// The returned stream shares the buffer, but it can't read over n bytes.
+// Putting to the returned stream appends to a copy, so it doesn't change the parent.
+// Overwriting the bytes with Put*At or WriteAt changes the parent because they are shared.
+func (bs *Stream) Sub(n int) (*Stream, error) {
+	if n < 0 {
+		return nil, bs.fail("Sub", n, ErrInvalidOffset)
+	}
+
+	b, err := bs.get("Sub", n)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := NewStreamBytes(b[:n:n])
+	sub.maxLen = bs.maxLen
+
+	return sub, nil
+}
+
 // Pad puts empty bytes (0x00) of le (len).
 func (bs *Stream) Pad(le int) error {
-	return bs.Put(make([]byte, le))
+	bs.buf = append(bs.buf, make([]byte, le)...)
+
+	return nil
 }
 
 // Read reads and sets p
+// It returns io.EOF if no bytes are left
 func (bs *Stream) Read(p []byte) (n int, err error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	if len(p) > 0 && bs.Len() == 0 {
+		return 0, io.EOF
+	}
+
 	return copy(p, bs.Get(len(p))), nil
 }
 
@@ -114,6 +228,161 @@ func (bs *Stream) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Seek sets offset for the next read, and returns the new offset
+// The offset can't be set out of the buffer
+// It returns the error of the stream if a read failed before
+func (bs *Stream) Seek(offset int64, whence int) (int64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = int64(bs.off) + offset
+	case io.SeekEnd:
+		abs = int64(len(bs.buf)) + offset
+	default:
+		return 0, ErrInvalidWhence
+	}
+
+	if abs < 0 || abs > int64(len(bs.buf)) {
+		return 0, ErrInvalidOffset
+	}
+
+	bs.off = int(abs)
+
+	return abs, nil
+}
+
+// ReadAt reads len(p) bytes at off of the buffer into p
+// It doesn't change offset of the stream
+func (bs *Stream) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, ErrInvalidOffset
+	}
+
+	if off >= int64(len(bs.buf)) {
+		return 0, io.EOF
+	}
+
+	n = copy(p, bs.buf[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// WriteAt writes p at off of the buffer
+// It overwrites the buffer, and extends it with 0x00 if off is over the end
+func (bs *Stream) WriteAt(p []byte, off int64) (n int, err error) {
+	if off < 0 || off > int64(maxInt-len(p)) {
+		return 0, ErrInvalidOffset
+	}
+
+	end := int(off) + len(p)
+	if end > len(bs.buf) {
+		bs.buf = append(bs.buf, make([]byte, end-len(bs.buf))...)
+	}
+
+	return copy(bs.buf[off:], p), nil
+}
+
+// ReadByte reads a byte
+// It returns io.EOF if no bytes are left
+func (bs *Stream) ReadByte() (byte, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	if bs.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	c := bs.buf[bs.off]
+	bs.off++
+
+	return c, nil
+}
+
+// UnreadByte moves offset back by a byte
+func (bs *Stream) UnreadByte() error {
+	if !bs.correct {
+		return bs.err
+	}
+
+	if bs.off <= 0 {
+		return ErrUnreadByte
+	}
+
+	bs.off--
+
+	return nil
+}
+
+// ReadRune reads an UTF-8 encoded rune
+// It returns io.EOF if no bytes are left
+func (bs *Stream) ReadRune() (r rune, size int, err error) {
+	if !bs.correct {
+		return 0, 0, bs.err
+	}
+
+	if bs.Len() == 0 {
+		return 0, 0, io.EOF
+	}
+
+	r, size = utf8.DecodeRune(bs.Bytes())
+	bs.off += size
+
+	return r, size, nil
+}
+
+// WriteTo writes the bytes left into w
+func (bs *Stream) WriteTo(w io.Writer) (n int64, err error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	m, err := w.Write(bs.Bytes())
+	bs.off += m
+	if err == nil && bs.Len() > 0 {
+		err = io.ErrShortWrite
+	}
+
+	return int64(m), err
+}
+
+// minRead is minimum size of a read of ReadFrom
+const minRead = 512
+
+// ReadFrom reads from r until io.EOF and puts the bytes
+func (bs *Stream) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		if cap(bs.buf)-len(bs.buf) < minRead {
+			bs.buf = append(bs.buf, make([]byte, minRead)...)[:len(bs.buf)]
+		}
+
+		m, err := r.Read(bs.buf[len(bs.buf):cap(bs.buf)])
+		if m < 0 {
+			panic("binary: reader returned negative count from Read")
+		}
+
+		bs.buf = bs.buf[:len(bs.buf)+m]
+		n += int64(m)
+
+		if err == io.EOF {
+			return n, nil
+		}
+
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
 /*
  * Data types
  * | name  | size | encode |                   range                   |
@@ -123,6 +392,8 @@ func (bs *Stream) Write(p []byte) (n int, err error) {
  *  SShort  2bytes  Big                   -32768 - 32767
  *  LShort  2bytes  Little                     0 - 65535
  *  LSShort 2bytes  Little                -32768 - 32767
+ *  Triad   3bytes  Big                 -8388608 - 8388607
+ *  UTriad  3bytes  Big                        0 - 16777215
  *  Int     4bytes  Big              -2147483648 - 2147483647
  *  Long    8bytes  Big     -9223372036854775808 - 9223372036854775807
  *  String  ?bytes  Big                        ? - ?
@@ -130,186 +401,384 @@ func (bs *Stream) Write(p []byte) (n int, err error) {
  *  LFloat  4bytes  Little     IEEE-754 32bits floating-point numbers
  *  Double  8bytes  Big        IEEE-754 64bits floating-point numbers
  *  LDouble 8bytes  Little     IEEE-754 64bits floating-point numbers
+ *  VarInt  ~5bytes ZigZag           -2147483648 - 2147483647
+ *  UVarInt ~5bytes LEB128                     0 - 4294967295
+ *  VarLong ~10bytes ZigZag -9223372036854775808 - 9223372036854775807
+ *  UVarLong ~10bytes LEB128                   0 - 18446744073709551615
  */
 
 // Byte gets an unsigned byte
 func (bs *Stream) Byte() (byte, error) {
-	return ReadEByte(bs.Get(ByteSize))
+	b, err := bs.get("Byte", ByteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadByte(b), nil
 }
 
 // SByte gets a signed byte
 func (bs *Stream) SByte() (int8, error) {
-	return ReadESByte(bs.Get(ByteSize))
+	b, err := bs.get("SByte", ByteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadSByte(b), nil
 }
 
 // PutByte puts an unsigned byte
 func (bs *Stream) PutByte(value byte) error {
-	return bs.Put(WriteByte(value))
+	bs.buf = AppendByte(bs.buf, value)
+
+	return nil
 }
 
 // PutSByte puts a signed byte
 func (bs *Stream) PutSByte(value int8) error {
-	return bs.Put(WriteSByte(value))
+	bs.buf = AppendSByte(bs.buf, value)
+
+	return nil
 }
 
 // Short gets an unsigned short
 func (bs *Stream) Short() (uint16, error) {
-	return ReadEUShort(bs.Get(ShortSize))
+	b, err := bs.get("Short", ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUShort(b), nil
 }
 
 // SShort gets a signed short
 func (bs *Stream) SShort() (int16, error) {
-	return ReadEShort(bs.Get(ShortSize))
+	b, err := bs.get("SShort", ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadShort(b), nil
 }
 
 // LShort gets an unsigned short with LittleEndian
 func (bs *Stream) LShort() (uint16, error) {
-	return ReadELUShort(bs.Get(ShortSize))
+	b, err := bs.get("LShort", ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUShort(b), nil
 }
 
 // LSShort gets a signed short with LittleEndian
 func (bs *Stream) LSShort() (int16, error) {
-	return ReadELShort(bs.Get(ShortSize))
+	b, err := bs.get("LSShort", ShortSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLShort(b), nil
 }
 
 // PutShort puts an unsigned short
 func (bs *Stream) PutShort(value uint16) error {
-	return bs.Put(WriteUShort(value))
+	bs.buf = AppendUShort(bs.buf, value)
+
+	return nil
 }
 
 // PutSShort puts a signed short
 func (bs *Stream) PutSShort(value int16) error {
-	return bs.Put(WriteShort(value))
+	bs.buf = AppendShort(bs.buf, value)
+
+	return nil
 }
 
 // PutLShort puts an unsigned short with LittleEndian
 func (bs *Stream) PutLShort(value uint16) error {
-	return bs.Put(WriteLUShort(value))
+	bs.buf = AppendLUShort(bs.buf, value)
+
+	return nil
 }
 
 // PutLSShort puts a signed short with LittleEndian
 func (bs *Stream) PutLSShort(value int16) error {
-	return bs.Put(WriteLShort(value))
+	bs.buf = AppendLShort(bs.buf, value)
+
+	return nil
+}
+
+// Triad gets a signed triad
+func (bs *Stream) Triad() (int32, error) {
+	b, err := bs.get("Triad", TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadTriad(b), nil
+}
+
+// PutTriad puts a signed triad
+func (bs *Stream) PutTriad(value int32) error {
+	bs.buf = AppendTriad(bs.buf, value)
+
+	return nil
+}
+
+// UTriad gets an unsigned triad
+func (bs *Stream) UTriad() (uint32, error) {
+	b, err := bs.get("UTriad", TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUTriad(b), nil
+}
+
+// PutUTriad puts an unsigned triad
+func (bs *Stream) PutUTriad(value uint32) error {
+	bs.buf = AppendUTriad(bs.buf, value)
+
+	return nil
+}
+
+// LTriad gets a signed triad with LittleEndian
+func (bs *Stream) LTriad() (int32, error) {
+	b, err := bs.get("LTriad", TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLTriad(b), nil
+}
+
+// PutLTriad puts a signed triad with LittleEndian
+func (bs *Stream) PutLTriad(value int32) error {
+	bs.buf = AppendLTriad(bs.buf, value)
+
+	return nil
+}
+
+// LUTriad gets an unsigned triad with LittleEndian
+func (bs *Stream) LUTriad() (uint32, error) {
+	b, err := bs.get("LUTriad", TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUTriad(b), nil
+}
+
+// PutLUTriad puts an unsigned triad with LittleEndian
+func (bs *Stream) PutLUTriad(value uint32) error {
+	bs.buf = AppendLUTriad(bs.buf, value)
+
+	return nil
 }
 
 // Int gets a signed int
 func (bs *Stream) Int() (int32, error) {
-	return ReadEInt(bs.Get(IntSize))
+	b, err := bs.get("Int", IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadInt(b), nil
 }
 
 // PutInt puts a signed int
 func (bs *Stream) PutInt(value int32) error {
-	return bs.Put(WriteInt(value))
+	bs.buf = AppendInt(bs.buf, value)
+
+	return nil
 }
 
 // UInt gets an unsigned int
 func (bs *Stream) UInt() (uint32, error) {
-	return ReadEUInt(bs.Get(IntSize))
+	b, err := bs.get("UInt", IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadUInt(b), nil
 }
 
 // PutUInt puts an unsigned int
 func (bs *Stream) PutUInt(value uint32) error {
-	return bs.Put(WriteUInt(value))
+	bs.buf = AppendUInt(bs.buf, value)
+
+	return nil
 }
 
 // LInt gets a signed int with LittleEndian
 func (bs *Stream) LInt() (int32, error) {
-	return ReadELInt(bs.Get(IntSize))
+	b, err := bs.get("LInt", IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLInt(b), nil
 }
 
 // PutLInt puts a signed int with LittleEndian
 func (bs *Stream) PutLInt(value int32) error {
-	return bs.Put(WriteLInt(value))
+	bs.buf = AppendLInt(bs.buf, value)
+
+	return nil
 }
 
 // LUInt gets an unsigned int with LittleEndian
 func (bs *Stream) LUInt() (uint32, error) {
-	return ReadELUInt(bs.Get(IntSize))
+	b, err := bs.get("LUInt", IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLUInt(b), nil
 }
 
 // PutLUInt puts an unsigned int with LittleEndian
 func (bs *Stream) PutLUInt(value uint32) error {
-	return bs.Put(WriteLUInt(value))
+	bs.buf = AppendLUInt(bs.buf, value)
+
+	return nil
 }
 
 // Long gets a signed long
 func (bs *Stream) Long() (int64, error) {
-	return ReadELong(bs.Get(LongSize))
+	b, err := bs.get("Long", LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLong(b), nil
 }
 
 // PutLong puts a signed long
 func (bs *Stream) PutLong(value int64) error {
-	return bs.Put(WriteLong(value))
+	bs.buf = AppendLong(bs.buf, value)
+
+	return nil
 }
 
 // LLong gets a signed long with LittleEndian
 func (bs *Stream) LLong() (int64, error) {
-	return ReadELLong(bs.Get(LongSize))
+	b, err := bs.get("LLong", LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLLong(b), nil
 }
 
 // PutLLong puts a signed long with LittleEndian
 func (bs *Stream) PutLLong(value int64) error {
-	return bs.Put(WriteLLong(value))
+	bs.buf = AppendLLong(bs.buf, value)
+
+	return nil
 }
 
 // ULong gets an unsigned long
 func (bs *Stream) ULong() (uint64, error) {
-	return ReadEULong(bs.Get(LongSize))
+	b, err := bs.get("ULong", LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadULong(b), nil
 }
 
 // PutULong puts an unsigned long
 func (bs *Stream) PutULong(value uint64) error {
-	return bs.Put(WriteULong(value))
+	bs.buf = AppendULong(bs.buf, value)
+
+	return nil
 }
 
 // LULong gets an unsigned long with LittleEndian
 func (bs *Stream) LULong() (uint64, error) {
-	return ReadELULong(bs.Get(LongSize))
+	b, err := bs.get("LULong", LongSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLULong(b), nil
 }
 
 // PutLULong puts an unsigned long with LittleEndian
 func (bs *Stream) PutLULong(value uint64) error {
-	return bs.Put(WriteLULong(value))
+	bs.buf = AppendLULong(bs.buf, value)
+
+	return nil
 }
 
 // Float gets a float
 func (bs *Stream) Float() (float32, error) {
-	return ReadEFloat(bs.Get(FloatSize))
+	b, err := bs.get("Float", FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadFloat(b), nil
 }
 
 // PutFloat puts a float
 func (bs *Stream) PutFloat(value float32) error {
-	return bs.Put(WriteFloat(value))
+	bs.buf = AppendFloat(bs.buf, value)
+
+	return nil
 }
 
 // LFloat gtes a float with LittleEndian
 func (bs *Stream) LFloat() (float32, error) {
-	return ReadELFloat(bs.Get(FloatSize))
+	b, err := bs.get("LFloat", FloatSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLFloat(b), nil
 }
 
 // PutLFloat puts a float
 func (bs *Stream) PutLFloat(value float32) error {
-	return bs.Put(WriteLFloat(value))
+	bs.buf = AppendLFloat(bs.buf, value)
+
+	return nil
 }
 
 // Double gets a double
 func (bs *Stream) Double() (float64, error) {
-	return ReadEDouble(bs.Get(DoubleSize))
+	b, err := bs.get("Double", DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadDouble(b), nil
 }
 
 // PutDouble puts a double
 func (bs *Stream) PutDouble(value float64) error {
-	return bs.Put(WriteDouble(value))
+	bs.buf = AppendDouble(bs.buf, value)
+
+	return nil
 }
 
 // LDouble gets a double with LittleEndian
 func (bs *Stream) LDouble() (float64, error) {
-	return ReadELDouble(bs.Get(DoubleSize))
+	b, err := bs.get("LDouble", DoubleSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return ReadLDouble(b), nil
 }
 
 // PutLDouble puts a double with LittleEndian
 func (bs *Stream) PutLDouble(value float64) error {
-	return bs.Put(WriteLDouble(value))
+	bs.buf = AppendLDouble(bs.buf, value)
+
+	return nil
 }
 
 // Bool gets a byte and returns as bool
@@ -332,6 +801,98 @@ func (bs *Stream) PutBool(value bool) error {
 	return bs.PutByte(val)
 }
 
+// VarInt gets a signed varint (ZigZag encoded)
+func (bs *Stream) VarInt() (int32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, n, err := ReadEVarInt(bs.Bytes())
+	if err != nil {
+		return 0, bs.fail("VarInt", varIntNeed(err, bs.Len()), err)
+	}
+
+	bs.Skip(n)
+
+	return value, nil
+}
+
+// PutVarInt puts a signed varint (ZigZag encoded)
+func (bs *Stream) PutVarInt(value int32) error {
+	bs.buf = AppendVarInt(bs.buf, value)
+
+	return nil
+}
+
+// UVarInt gets an unsigned varint
+func (bs *Stream) UVarInt() (uint32, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, n, err := ReadEUVarInt(bs.Bytes())
+	if err != nil {
+		return 0, bs.fail("UVarInt", varIntNeed(err, bs.Len()), err)
+	}
+
+	bs.Skip(n)
+
+	return value, nil
+}
+
+// PutUVarInt puts an unsigned varint
+func (bs *Stream) PutUVarInt(value uint32) error {
+	bs.buf = AppendUVarInt(bs.buf, value)
+
+	return nil
+}
+
+// VarLong gets a signed varlong (ZigZag encoded)
+func (bs *Stream) VarLong() (int64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, n, err := ReadEVarLong(bs.Bytes())
+	if err != nil {
+		return 0, bs.fail("VarLong", varIntNeed(err, bs.Len()), err)
+	}
+
+	bs.Skip(n)
+
+	return value, nil
+}
+
+// PutVarLong puts a signed varlong (ZigZag encoded)
+func (bs *Stream) PutVarLong(value int64) error {
+	bs.buf = AppendVarLong(bs.buf, value)
+
+	return nil
+}
+
+// UVarLong gets an unsigned varlong
+func (bs *Stream) UVarLong() (uint64, error) {
+	if !bs.correct {
+		return 0, bs.err
+	}
+
+	value, n, err := ReadEUVarLong(bs.Bytes())
+	if err != nil {
+		return 0, bs.fail("UVarLong", varIntNeed(err, bs.Len()), err)
+	}
+
+	bs.Skip(n)
+
+	return value, nil
+}
+
+// PutUVarLong puts an unsigned varlong
+func (bs *Stream) PutUVarLong(value uint64) error {
+	bs.buf = AppendUVarLong(bs.buf, value)
+
+	return nil
+}
+
 // NewOrderStream returns new Stream
 func NewOrderStream(order Order) *OrderStream {
 	return NewOrderStreamBytes(order, []byte{})
@@ -351,18 +912,9 @@ type OrderStream struct {
 	Order Order
 }
 
-func (bs *OrderStream) get(size int) ([]byte, error) {
-	b := bs.Get(size)
-	if len(b) != size {
-		return nil, ErrNotEnought
-	}
-
-	return b, nil
-}
-
 // Short get an unsigned short with the order
 func (bs *OrderStream) Short() (value uint16, err error) {
-	b, err := bs.get(ShortSize)
+	b, err := bs.get("Short", ShortSize)
 	if err != nil {
 		return 0, err
 	}
@@ -372,7 +924,7 @@ func (bs *OrderStream) Short() (value uint16, err error) {
 
 // SShort get a signed short with the order
 func (bs *OrderStream) SShort() (value int16, err error) {
-	b, err := bs.get(ShortSize)
+	b, err := bs.get("SShort", ShortSize)
 	if err != nil {
 		return 0, err
 	}
@@ -382,17 +934,55 @@ func (bs *OrderStream) SShort() (value int16, err error) {
 
 // PutShort puts an unsigned short with the order
 func (bs *OrderStream) PutShort(value uint16) error {
-	return bs.Put(bs.Order.PutUShort(value))
+	bs.buf = bs.Order.AppendUShort(bs.buf, value)
+
+	return nil
 }
 
 // PutSShort puts a signed short with the order
 func (bs *OrderStream) PutSShort(value int16) error {
-	return bs.Put(bs.Order.PutShort(value))
+	bs.buf = bs.Order.AppendShort(bs.buf, value)
+
+	return nil
+}
+
+// Triad gets a signed triad with the order
+func (bs *OrderStream) Triad() (value int32, err error) {
+	b, err := bs.get("Triad", TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.Triad(b), nil
+}
+
+// PutTriad puts a signed triad with the order
+func (bs *OrderStream) PutTriad(value int32) error {
+	bs.buf = bs.Order.AppendTriad(bs.buf, value)
+
+	return nil
+}
+
+// UTriad gets an unsigned triad with the order
+func (bs *OrderStream) UTriad() (value uint32, err error) {
+	b, err := bs.get("UTriad", TriadSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return bs.Order.UTriad(b), nil
+}
+
+// PutUTriad puts an unsigned triad with the order
+func (bs *OrderStream) PutUTriad(value uint32) error {
+	bs.buf = bs.Order.AppendUTriad(bs.buf, value)
+
+	return nil
 }
 
 // Int get a signed int with the order
 func (bs *OrderStream) Int() (value int32, err error) {
-	b, err := bs.get(IntSize)
+	b, err := bs.get("Int", IntSize)
 	if err != nil {
 		return 0, err
 	}
@@ -402,12 +992,14 @@ func (bs *OrderStream) Int() (value int32, err error) {
 
 // PutInt puts a signed int with the order
 func (bs *OrderStream) PutInt(value int32) error {
-	return bs.Put(bs.Order.PutInt(value))
+	bs.buf = bs.Order.AppendInt(bs.buf, value)
+
+	return nil
 }
 
 // UInt get an unsigned int with the order
 func (bs *OrderStream) UInt() (value uint32, err error) {
-	b, err := bs.get(IntSize)
+	b, err := bs.get("UInt", IntSize)
 	if err != nil {
 		return 0, err
 	}
@@ -417,12 +1009,14 @@ func (bs *OrderStream) UInt() (value uint32, err error) {
 
 // PutUInt puts an unsigned int with the order
 func (bs *OrderStream) PutUInt(value uint32) error {
-	return bs.Put(bs.Order.PutUInt(value))
+	bs.buf = bs.Order.AppendUInt(bs.buf, value)
+
+	return nil
 }
 
 // Long gets a signed long with the order
 func (bs *OrderStream) Long() (value int64, err error) {
-	b, err := bs.get(LongSize)
+	b, err := bs.get("Long", LongSize)
 	if err != nil {
 		return 0, err
 	}
@@ -432,12 +1026,14 @@ func (bs *OrderStream) Long() (value int64, err error) {
 
 // PutLong puts a signed long with the order
 func (bs *OrderStream) PutLong(value int64) error {
-	return bs.Put(bs.Order.PutLong(value))
+	bs.buf = bs.Order.AppendLong(bs.buf, value)
+
+	return nil
 }
 
 // ULong gets an unsigned long with the order
 func (bs *OrderStream) ULong() (value uint64, err error) {
-	b, err := bs.get(LongSize)
+	b, err := bs.get("ULong", LongSize)
 	if err != nil {
 		return 0, err
 	}
@@ -447,12 +1043,14 @@ func (bs *OrderStream) ULong() (value uint64, err error) {
 
 // PutULong puts an unsigned long with the order
 func (bs *OrderStream) PutULong(value uint64) error {
-	return bs.Put(bs.Order.PutULong(value))
+	bs.buf = bs.Order.AppendULong(bs.buf, value)
+
+	return nil
 }
 
 // Float gets a float with the order
 func (bs *OrderStream) Float() (value float32, err error) {
-	b, err := bs.get(FloatSize)
+	b, err := bs.get("Float", FloatSize)
 	if err != nil {
 		return 0, err
 	}
@@ -462,12 +1060,14 @@ func (bs *OrderStream) Float() (value float32, err error) {
 
 // PutFloat puts a float with the order
 func (bs *OrderStream) PutFloat(value float32) error {
-	return bs.Put(bs.Order.PutFloat(value))
+	bs.buf = bs.Order.AppendFloat(bs.buf, value)
+
+	return nil
 }
 
 // Double gets a double with the order
 func (bs *OrderStream) Double() (value float64, err error) {
-	b, err := bs.get(DoubleSize)
+	b, err := bs.get("Double", DoubleSize)
 	if err != nil {
 		return 0, err
 	}
@@ -477,5 +1077,7 @@ func (bs *OrderStream) Double() (value float64, err error) {
 
 // PutDouble puts a double with the order
 func (bs *OrderStream) PutDouble(value float64) error {
-	return bs.Put(bs.Order.PutDouble(value))
+	bs.buf = bs.Order.AppendDouble(bs.buf, value)
+
+	return nil
 }
@@ -11,6 +11,7 @@ package binary
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -197,3 +198,366 @@ func TestStreamPad(t *testing.T) {
 		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
 	}
 }
+
+func TestStreamVarInt(t *testing.T) {
+	tests := []struct {
+		value int32
+		data  []byte
+	}{
+		{0, []byte{0x00}},
+		{-1, []byte{0x01}},
+		{1, []byte{0x02}},
+		{63, []byte{0x7e}},
+		{-64, []byte{0x7f}},
+		{64, []byte{0x80, 0x01}},
+		{2147483647, []byte{0xfe, 0xff, 0xff, 0xff, 0x0f}},
+		{-2147483648, []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, test := range tests {
+		stream := NewStream()
+		if err := stream.PutVarInt(test.value); err != nil {
+			t.Fatalf("Failed to put varint Error: %s", err)
+		}
+
+		ret := stream.Bytes()
+		if !bytes.Equal(ret, test.data) {
+			t.Fatalf("Expected %d for bytes, but %d", test.data, ret)
+		}
+
+		value, err := stream.VarInt()
+		if err != nil {
+			t.Fatalf("Failed to get varint Error: %s", err)
+		}
+
+		if value != test.value {
+			t.Fatalf("Expected %d for varint, but %d", test.value, value)
+		}
+	}
+}
+
+func TestStreamUVarLong(t *testing.T) {
+	tests := []struct {
+		value uint64
+		data  []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{300, []byte{0xac, 0x02}},
+		{18446744073709551615, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+
+	for _, test := range tests {
+		stream := NewStream()
+		if err := stream.PutUVarLong(test.value); err != nil {
+			t.Fatalf("Failed to put varlong Error: %s", err)
+		}
+
+		ret := stream.Bytes()
+		if !bytes.Equal(ret, test.data) {
+			t.Fatalf("Expected %d for bytes, but %d", test.data, ret)
+		}
+
+		value, err := stream.UVarLong()
+		if err != nil {
+			t.Fatalf("Failed to get varlong Error: %s", err)
+		}
+
+		if value != test.value {
+			t.Fatalf("Expected %d for varlong, but %d", test.value, value)
+		}
+	}
+}
+
+func TestStreamVarIntError(t *testing.T) {
+	var derr *DecodeError
+
+	// truncated
+	stream := NewStreamBytes([]byte{0x80, 0x80})
+	_, err := stream.UVarInt()
+	if !errors.Is(err, ErrNotEnought) || !errors.As(err, &derr) {
+		t.Fatalf("Expected %v for truncated varint, but %v", ErrNotEnought, err)
+	}
+
+	if derr.Need != 3 || derr.Avail != 2 {
+		t.Fatalf("Expected need %d and available %d, but %d and %d", 3, 2, derr.Need, derr.Avail)
+	}
+
+	// over-long
+	stream = NewStreamBytes([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01})
+	_, err = stream.UVarInt()
+	if !errors.Is(err, ErrVarIntOverflow) || !errors.As(err, &derr) {
+		t.Fatalf("Expected %v for over-long varint, but %v", ErrVarIntOverflow, err)
+	}
+
+	if derr.Need != 0 {
+		t.Fatalf("Expected %d for need of over-long varint, but %d", 0, derr.Need)
+	}
+
+	stream = NewStreamBytes([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02})
+	if _, err := stream.VarLong(); !errors.Is(err, ErrVarIntOverflow) {
+		t.Fatalf("Expected %v for over-long varlong, but %v", ErrVarIntOverflow, err)
+	}
+}
+
+func TestStreamTriad(t *testing.T) {
+	stream := NewStream()
+
+	if err := stream.PutTriad(-2); err != nil {
+		t.Fatalf("Failed to put triad Error: %s", err)
+	}
+
+	if err := stream.PutLUTriad(0x010203); err != nil {
+		t.Fatalf("Failed to put triad Error: %s", err)
+	}
+
+	exp := []byte{0xff, 0xff, 0xfe, 0x03, 0x02, 0x01}
+	ret := stream.Bytes()
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	triad, err := stream.Triad()
+	if err != nil {
+		t.Fatalf("Failed to get triad Error: %s", err)
+	}
+
+	if triad != -2 {
+		t.Fatalf("Expected %d for triad, but %d", -2, triad)
+	}
+
+	utriad, err := stream.LUTriad()
+	if err != nil {
+		t.Fatalf("Failed to get triad Error: %s", err)
+	}
+
+	if utriad != 0x010203 {
+		t.Fatalf("Expected %d for triad, but %d", 0x010203, utriad)
+	}
+
+	if _, err := stream.UTriad(); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for triad, but %v", ErrNotEnought, err)
+	}
+}
+
+func TestStreamLDouble(t *testing.T) {
+	stream := NewStream()
+	if err := stream.PutLDouble(1); err != nil {
+		t.Fatalf("Failed to put double Error: %s", err)
+	}
+
+	exp := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f}
+	ret := stream.Bytes()
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	value, err := stream.LDouble()
+	if err != nil {
+		t.Fatalf("Failed to get double Error: %s", err)
+	}
+
+	if value != 1 {
+		t.Fatalf("Expected %f for double, but %f", 1.0, value)
+	}
+}
+
+func TestStreamErr(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x00, 0x01, 0x02, 0x03, 0x04})
+
+	stream.Short()
+	stream.Int() // fails
+
+	if _, err := stream.Byte(); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for byte after a failure, but %v", ErrNotEnought, err)
+	}
+
+	if stream.Off() != 2 {
+		t.Fatalf("Expected %d for offset, but %d", 2, stream.Off())
+	}
+
+	if err := stream.Err(); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for error, but %v", ErrNotEnought, err)
+	}
+
+	if off := stream.ErrOff(); off != 2 {
+		t.Fatalf("Expected %d for error offset, but %d", 2, off)
+	}
+
+	stream.SetBytes(Magic)
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Expected no error after reset, but %v", err)
+	}
+}
+
+func TestStreamDecodeError(t *testing.T) {
+	stream := NewStreamBytes([]byte{0x00, 0x01, 0x02, 0x03, 0x04})
+
+	stream.Short()
+
+	_, err := stream.Int()
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("Expected a DecodeError, but %v", err)
+	}
+
+	exp := DecodeError{Off: 2, Type: "Int", Need: IntSize, Avail: 3, Err: ErrNotEnought}
+	if *derr != exp {
+		t.Fatalf("Expected %+v for error, but %+v", exp, *derr)
+	}
+
+	msg := "binary: reading Int at offset 2: not enough bytes (need 4, available 3)"
+	if err.Error() != msg {
+		t.Fatalf("Expected %s for message, but %s", msg, err.Error())
+	}
+
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected the error matching %v", ErrNotEnought)
+	}
+}
+
+func TestReadDecodeError(t *testing.T) {
+	var v int32
+	for _, data := range []interface{}{&v, v} {
+		err := Read(bytes.NewReader([]byte{0x01, 0x02}), BigEndian, data)
+
+		var derr *DecodeError
+		if !errors.As(err, &derr) {
+			t.Fatalf("Expected a DecodeError for %T, but %v", data, err)
+		}
+
+		exp := DecodeError{Off: -1, Type: "int32", Need: IntSize, Avail: 2, Err: ErrNotEnought}
+		if *derr != exp {
+			t.Fatalf("Expected %+v for error, but %+v", exp, *derr)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	prefix := []byte{0xff}
+
+	tests := []struct {
+		name string
+		got  []byte
+		exp  []byte
+	}{
+		{"AppendShort", AppendShort(prefix, -2), append([]byte{0xff}, WriteShort(-2)...)},
+		{"AppendLUTriad", AppendLUTriad(prefix, 0x123456), append([]byte{0xff}, WriteLUTriad(0x123456)...)},
+		{"AppendLInt", AppendLInt(prefix, -3), append([]byte{0xff}, WriteLInt(-3)...)},
+		{"AppendULong", AppendULong(prefix, 0x0102030405060708), append([]byte{0xff}, WriteULong(0x0102030405060708)...)},
+		{"AppendLDouble", AppendLDouble(prefix, 1.5), append([]byte{0xff}, WriteLDouble(1.5)...)},
+		{"AppendVarLong", AppendVarLong(prefix, -300), append([]byte{0xff}, WriteVarLong(-300)...)},
+		{"BigEndian.AppendInt", BigEndian.AppendInt(prefix, 7), append([]byte{0xff}, BigEndian.PutInt(7)...)},
+		{"LittleEndian.AppendFloat", LittleEndian.AppendFloat(prefix, 2.5), append([]byte{0xff}, LittleEndian.PutFloat(2.5)...)},
+	}
+
+	for _, test := range tests {
+		if !bytes.Equal(test.got, test.exp) {
+			t.Errorf("%s: Expected % x for bytes, but % x", test.name, test.exp, test.got)
+		}
+	}
+}
+
+func TestStreamPutAllocs(t *testing.T) {
+	stream := NewStream()
+	stream.buf = make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		stream.buf = stream.buf[:0]
+		stream.PutInt(1)
+		stream.PutLLong(2)
+		stream.PutDouble(3)
+		stream.PutVarInt(-4)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected 0 for allocs, but %v", allocs)
+	}
+}
+
+func BenchmarkStreamPutInt(b *testing.B) {
+	stream := NewStream()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		stream.buf = stream.buf[:0]
+		stream.PutInt(int32(i))
+	}
+}
+
+func BenchmarkStreamPutIntWrite(b *testing.B) {
+	stream := NewStream()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		stream.buf = stream.buf[:0]
+		stream.Put(WriteInt(int32(i)))
+	}
+}
+
+func BenchmarkStreamPutLong(b *testing.B) {
+	stream := NewStream()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		stream.buf = stream.buf[:0]
+		stream.PutLong(int64(i))
+	}
+}
+
+func BenchmarkStreamPutLongWrite(b *testing.B) {
+	stream := NewStream()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		stream.buf = stream.buf[:0]
+		stream.Put(WriteLong(int64(i)))
+	}
+}
+
+func TestStreamSub(t *testing.T) {
+	stream := NewStreamBytes(append([]byte(nil), Magic...))
+	stream.Skip(2)
+
+	sub, err := stream.Sub(3)
+	if err != nil {
+		t.Fatalf("Failed to get a sub stream Error: %s", err)
+	}
+
+	if stream.Off() != 5 {
+		t.Fatalf("Expected %d for offset, but %d", 5, stream.Off())
+	}
+
+	val, _ := sub.Short()
+	if val != 0x0203 {
+		t.Fatalf("Expected %x for short, but %x", 0x0203, val)
+	}
+
+	// the sub stream can't read over its bound
+	_, err = sub.Short()
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+
+	// putting to the sub stream doesn't overwrite the parent
+	sub.PutByte(0xff)
+
+	b, _ := stream.Byte()
+	if b != 0x05 {
+		t.Fatalf("Expected %x for byte, but %x", 0x05, b)
+	}
+
+	// overwriting shares the bytes with the parent
+	sub, _ = stream.Sub(1)
+	sub.PutByteAt(0, 0xee)
+
+	if b, _ := stream.ByteAt(stream.Off() - 1); b != 0xee {
+		t.Fatalf("Expected %x for byte, but %x", 0xee, b)
+	}
+
+	_, err = stream.Sub(MagicLen)
+	if !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v, but %v", ErrNotEnought, err)
+	}
+}
@@ -0,0 +1,328 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+)
+
+// DefaultMaxLen is the default max length of strings and byte arrays
+const DefaultMaxLen = 1 << 20 // 1MiB
+
+const maxInt = int(^uint(0) >> 1)
+
+var maxUInt32 uint64 = math.MaxUint32
+
+var (
+	// ErrTooLong is returned when a length is over the max length
+	ErrTooLong = errors.New("binary: length is too long")
+
+	// ErrPrefixOverflow is returned when a length can't be stored in the prefix
+	ErrPrefixOverflow = errors.New("binary: length overflows the prefix")
+
+	// ErrUnknownPrefix is returned when an unknown prefix is used
+	ErrUnknownPrefix = errors.New("binary: unknown prefix")
+
+	// ErrNullChar is returned when a null terminated string contains 0x00
+	ErrNullChar = errors.New("binary: string contains a null character")
+)
+
+// Prefix is a kind of length prefix for strings and byte arrays
+type Prefix int
+
+const (
+	// PrefixByte is an unsigned byte prefix
+	PrefixByte Prefix = iota
+
+	// PrefixShort is an unsigned short prefix
+	PrefixShort
+
+	// PrefixLShort is an unsigned short prefix with LittleEndian
+	PrefixLShort
+
+	// PrefixInt is an unsigned int prefix
+	PrefixInt
+
+	// PrefixLInt is an unsigned int prefix with LittleEndian
+	PrefixLInt
+
+	// PrefixVarInt is an unsigned varint prefix
+	PrefixVarInt
+)
+
+// Max returns max length which can be stored in the prefix
+func (p Prefix) Max() int {
+	switch p {
+	case PrefixByte:
+		return 0xff
+	case PrefixShort, PrefixLShort:
+		return 0xffff
+	case PrefixInt, PrefixLInt, PrefixVarInt:
+		if uint64(maxInt) < maxUInt32 { // 32bit int
+			return maxInt
+		}
+
+		return int(maxUInt32)
+	}
+
+	return 0
+}
+
+// String returns name of the prefix
+func (p Prefix) String() string {
+	switch p {
+	case PrefixByte:
+		return "Byte"
+	case PrefixShort:
+		return "Short"
+	case PrefixLShort:
+		return "LShort"
+	case PrefixInt:
+		return "Int"
+	case PrefixLInt:
+		return "LInt"
+	case PrefixVarInt:
+		return "VarInt"
+	}
+
+	return "Unknown"
+}
+
+// SetMaxLen sets max length of strings and byte arrays on reading
+// If n is 0 or less, the length is not limited
+func (bs *Stream) SetMaxLen(n int) {
+	bs.maxLen = n
+}
+
+// MaxLen returns max length of strings and byte arrays on reading
+func (bs *Stream) MaxLen() int {
+	return bs.maxLen
+}
+
+// Length gets a length with the prefix
+func (bs *Stream) Length(prefix Prefix) (int, error) {
+	var ln uint64
+	switch prefix {
+	case PrefixByte:
+		v, err := bs.Byte()
+		if err != nil {
+			return 0, err
+		}
+
+		ln = uint64(v)
+	case PrefixShort:
+		v, err := bs.Short()
+		if err != nil {
+			return 0, err
+		}
+
+		ln = uint64(v)
+	case PrefixLShort:
+		v, err := bs.LShort()
+		if err != nil {
+			return 0, err
+		}
+
+		ln = uint64(v)
+	case PrefixInt:
+		v, err := bs.UInt()
+		if err != nil {
+			return 0, err
+		}
+
+		ln = uint64(v)
+	case PrefixLInt:
+		v, err := bs.LUInt()
+		if err != nil {
+			return 0, err
+		}
+
+		ln = uint64(v)
+	case PrefixVarInt:
+		v, err := bs.UVarInt()
+		if err != nil {
+			return 0, err
+		}
+
+		ln = uint64(v)
+	default:
+		return 0, bs.fail("Length", 0, ErrUnknownPrefix)
+	}
+
+	if ln > uint64(prefix.Max()) || (bs.maxLen > 0 && ln > uint64(bs.maxLen)) {
+		return 0, bs.fail("Length", int(ln), ErrTooLong)
+	}
+
+	return int(ln), nil
+}
+
+// PutLength puts a length with the prefix
+func (bs *Stream) PutLength(prefix Prefix, ln int) error {
+	b, err := AppendLength(bs.buf, prefix, ln)
+	if err != nil {
+		return err
+	}
+
+	bs.buf = b
+
+	return nil
+}
+
+// WriteLength returns a length with the prefix as bytes
+func WriteLength(prefix Prefix, ln int) ([]byte, error) {
+	return AppendLength(nil, prefix, ln)
+}
+
+// AppendLength appends a length with the prefix to dst and returns the extended buffer
+func AppendLength(dst []byte, prefix Prefix, ln int) ([]byte, error) {
+	max := prefix.Max()
+	if max == 0 {
+		return dst, ErrUnknownPrefix
+	}
+
+	if ln < 0 || ln > max {
+		return dst, ErrPrefixOverflow
+	}
+
+	switch prefix {
+	case PrefixByte:
+		return AppendByte(dst, byte(ln)), nil
+	case PrefixShort:
+		return AppendUShort(dst, uint16(ln)), nil
+	case PrefixLShort:
+		return AppendLUShort(dst, uint16(ln)), nil
+	case PrefixInt:
+		return AppendUInt(dst, uint32(ln)), nil
+	case PrefixLInt:
+		return AppendLUInt(dst, uint32(ln)), nil
+	case PrefixVarInt:
+		return AppendUVarInt(dst, uint32(ln)), nil
+	}
+
+	return dst, ErrUnknownPrefix
+}
+
+// ByteArray gets bytes with a length prefix
+func (bs *Stream) ByteArray(prefix Prefix) ([]byte, error) {
+	ln, err := bs.Length(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := bs.get("ByteArray", ln)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, ln)
+	copy(b, v)
+
+	return b, nil
+}
+
+// PutByteArray puts bytes with a length prefix
+func (bs *Stream) PutByteArray(prefix Prefix, value []byte) error {
+	err := bs.PutLength(prefix, len(value))
+	if err != nil {
+		return err
+	}
+
+	return bs.Put(value)
+}
+
+// String gets a string with a length prefix
+func (bs *Stream) String(prefix Prefix) (string, error) {
+	ln, err := bs.Length(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := bs.get("String", ln)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// PutString puts a string with a length prefix
+func (bs *Stream) PutString(prefix Prefix, value string) error {
+	err := bs.PutLength(prefix, len(value))
+	if err != nil {
+		return err
+	}
+
+	bs.buf = append(bs.buf, value...)
+
+	return nil
+}
+
+// CString gets a null terminated string
+func (bs *Stream) CString() (string, error) {
+	if !bs.correct {
+		return "", bs.err
+	}
+
+	b := bs.Bytes()
+
+	ln := bytes.IndexByte(b, 0x00)
+	if ln < 0 {
+		if bs.maxLen > 0 && len(b) > bs.maxLen {
+			return "", bs.fail("CString", len(b), ErrTooLong)
+		}
+
+		return "", bs.fail("CString", len(b)+1, ErrNotEnought)
+	}
+
+	if bs.maxLen > 0 && ln > bs.maxLen {
+		return "", bs.fail("CString", ln+1, ErrTooLong)
+	}
+
+	value := string(bs.Get(ln))
+	bs.Skip(1) // null
+
+	return value, nil
+}
+
+// PutCString puts a null terminated string
+func (bs *Stream) PutCString(value string) error {
+	if strings.IndexByte(value, 0x00) >= 0 {
+		return ErrNullChar
+	}
+
+	bs.buf = append(bs.buf, value...)
+	bs.buf = append(bs.buf, 0x00)
+
+	return nil
+}
+
+// FixedString gets a string of n bytes and trims trailing padding (0x00)
+func (bs *Stream) FixedString(n int) (string, error) {
+	b, err := bs.get("FixedString", n)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimRight(b, "\x00")), nil
+}
+
+// PutFixedString puts a string padded with 0x00 to n bytes
+func (bs *Stream) PutFixedString(n int, value string) error {
+	if len(value) > n {
+		return ErrTooLong
+	}
+
+	bs.buf = append(bs.buf, value...)
+
+	return bs.Pad(n - len(value))
+}
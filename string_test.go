@@ -0,0 +1,160 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStreamString(t *testing.T) {
+	tests := []struct {
+		prefix Prefix
+		data   []byte
+	}{
+		{PrefixByte, []byte{0x03, 'a', 'b', 'c'}},
+		{PrefixShort, []byte{0x00, 0x03, 'a', 'b', 'c'}},
+		{PrefixLShort, []byte{0x03, 0x00, 'a', 'b', 'c'}},
+		{PrefixInt, []byte{0x00, 0x00, 0x00, 0x03, 'a', 'b', 'c'}},
+		{PrefixLInt, []byte{0x03, 0x00, 0x00, 0x00, 'a', 'b', 'c'}},
+		{PrefixVarInt, []byte{0x03, 'a', 'b', 'c'}},
+	}
+
+	for _, test := range tests {
+		stream := NewStream()
+		if err := stream.PutString(test.prefix, "abc"); err != nil {
+			t.Fatalf("Failed to put string with %s Error: %s", test.prefix, err)
+		}
+
+		ret := stream.Bytes()
+		if !bytes.Equal(ret, test.data) {
+			t.Fatalf("Expected %d for bytes with %s, but %d", test.data, test.prefix, ret)
+		}
+
+		value, err := stream.String(test.prefix)
+		if err != nil {
+			t.Fatalf("Failed to get string with %s Error: %s", test.prefix, err)
+		}
+
+		if value != "abc" {
+			t.Fatalf("Expected %s for string with %s, but %s", "abc", test.prefix, value)
+		}
+	}
+}
+
+func TestStreamByteArray(t *testing.T) {
+	stream := NewStream()
+	if err := stream.PutByteArray(PrefixVarInt, Magic); err != nil {
+		t.Fatalf("Failed to put bytes Error: %s", err)
+	}
+
+	ret, err := stream.ByteArray(PrefixVarInt)
+	if err != nil {
+		t.Fatalf("Failed to get bytes Error: %s", err)
+	}
+
+	if !bytes.Equal(ret, Magic) {
+		t.Fatalf("Expected %d for bytes, but %d", Magic, ret)
+	}
+
+	if err := stream.PutByteArray(PrefixByte, make([]byte, 256)); err != ErrPrefixOverflow {
+		t.Fatalf("Expected %v for overflowed prefix, but %v", ErrPrefixOverflow, err)
+	}
+}
+
+func TestStreamMaxLen(t *testing.T) {
+	// hostile prefix
+	stream := NewStreamBytes([]byte{0xff, 0xff, 0xff, 0xff, 'a'})
+	if _, err := stream.String(PrefixInt); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("Expected %v for too long string, but %v", ErrTooLong, err)
+	}
+
+	stream = NewStreamBytes([]byte{0x05, 'a', 'b', 'c', 'd', 'e'})
+	stream.SetMaxLen(4)
+	if _, err := stream.String(PrefixByte); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("Expected %v for too long string, but %v", ErrTooLong, err)
+	}
+
+	// truncated
+	stream = NewStreamBytes([]byte{0x05, 'a', 'b'})
+	if _, err := stream.ByteArray(PrefixByte); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for truncated bytes, but %v", ErrNotEnought, err)
+	}
+}
+
+func TestStreamCString(t *testing.T) {
+	stream := NewStream()
+	if err := stream.PutCString("abc"); err != nil {
+		t.Fatalf("Failed to put string Error: %s", err)
+	}
+
+	if err := stream.PutCString("a\x00c"); err != ErrNullChar {
+		t.Fatalf("Expected %v for string, but %v", ErrNullChar, err)
+	}
+
+	exp := []byte{'a', 'b', 'c', 0x00}
+	ret := stream.Bytes()
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	value, err := stream.CString()
+	if err != nil {
+		t.Fatalf("Failed to get string Error: %s", err)
+	}
+
+	if value != "abc" {
+		t.Fatalf("Expected %s for string, but %s", "abc", value)
+	}
+
+	if stream.Len() != 0 {
+		t.Fatalf("Expected %d for len, but %d", 0, stream.Len())
+	}
+
+	stream = NewStreamBytes([]byte{'a', 'b', 'c'})
+	if _, err := stream.CString(); !errors.Is(err, ErrNotEnought) {
+		t.Fatalf("Expected %v for unterminated string, but %v", ErrNotEnought, err)
+	}
+}
+
+func TestStreamFixedString(t *testing.T) {
+	stream := NewStream()
+	if err := stream.PutFixedString(6, "abc"); err != nil {
+		t.Fatalf("Failed to put string Error: %s", err)
+	}
+
+	if err := stream.PutFixedString(2, "abc"); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("Expected %v for string, but %v", ErrTooLong, err)
+	}
+
+	exp := []byte{'a', 'b', 'c', 0x00, 0x00, 0x00}
+	ret := stream.Bytes()
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	value, err := stream.FixedString(6)
+	if err != nil {
+		t.Fatalf("Failed to get string Error: %s", err)
+	}
+
+	if value != "abc" {
+		t.Fatalf("Expected %s for string, but %s", "abc", value)
+	}
+
+	stream = NewStreamBytes(exp)
+	_, err = stream.FixedString(-1)
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || !errors.Is(err, ErrInvalidOffset) || derr.Need != -1 {
+		t.Fatalf("Expected %v for negative size, but %v", ErrInvalidOffset, err)
+	}
+}
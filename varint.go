@@ -0,0 +1,173 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"errors"
+)
+
+const (
+	// MaxVarIntSize is max byte size of VarInt
+	MaxVarIntSize = 5
+
+	// MaxVarLongSize is max byte size of VarLong
+	MaxVarLongSize = 10
+)
+
+// ErrVarIntOverflow is returned when a varint is longer than its type
+var ErrVarIntOverflow = errors.New("binary: varint overflows")
+
+// varIntNeed returns count of bytes needed to read a varint for an error of ReadE functions
+// All available bytes of a truncated varint have the continuation bit, so it needs at least one more byte.
+// An overflowed varint doesn't need more bytes.
+func varIntNeed(err error, avail int) int {
+	if err == ErrNotEnought {
+		return avail + 1
+	}
+
+	return 0
+}
+
+// VarInt is LEB128 (7 bits per byte, lower groups first)
+// Signed values are encoded with ZigZag encoding
+
+// ReadEUVarInt reads an unsigned varint and returns it with read size
+func ReadEUVarInt(v []byte) (uint32, int, error) {
+	var value uint32
+	for i := 0; i < MaxVarIntSize; i++ {
+		if i >= len(v) {
+			return 0, 0, ErrNotEnought
+		}
+
+		b := v[i]
+		if i == MaxVarIntSize-1 && b > 0x0f {
+			return 0, 0, ErrVarIntOverflow
+		}
+
+		value |= uint32(b&0x7f) << (7 * uint(i))
+		if b&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+
+	return 0, 0, ErrVarIntOverflow
+}
+
+// ReadEVarInt reads a signed varint and returns it with read size
+func ReadEVarInt(v []byte) (int32, int, error) {
+	value, n, err := ReadEUVarInt(v)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return DecodeZigZag32(value), n, nil
+}
+
+// ReadEUVarLong reads an unsigned varlong and returns it with read size
+func ReadEUVarLong(v []byte) (uint64, int, error) {
+	var value uint64
+	for i := 0; i < MaxVarLongSize; i++ {
+		if i >= len(v) {
+			return 0, 0, ErrNotEnought
+		}
+
+		b := v[i]
+		if i == MaxVarLongSize-1 && b > 0x01 {
+			return 0, 0, ErrVarIntOverflow
+		}
+
+		value |= uint64(b&0x7f) << (7 * uint(i))
+		if b&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+
+	return 0, 0, ErrVarIntOverflow
+}
+
+// ReadEVarLong reads a signed varlong and returns it with read size
+func ReadEVarLong(v []byte) (int64, int, error) {
+	value, n, err := ReadEUVarLong(v)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return DecodeZigZag64(value), n, nil
+}
+
+// WriteUVarInt returns an unsigned varint as bytes
+func WriteUVarInt(v uint32) []byte {
+	return AppendUVarInt(make([]byte, 0, MaxVarIntSize), v)
+}
+
+// WriteVarInt returns a signed varint as bytes
+func WriteVarInt(v int32) []byte {
+	return WriteUVarInt(EncodeZigZag32(v))
+}
+
+// WriteUVarLong returns an unsigned varlong as bytes
+func WriteUVarLong(v uint64) []byte {
+	return AppendUVarLong(make([]byte, 0, MaxVarLongSize), v)
+}
+
+// WriteVarLong returns a signed varlong as bytes
+func WriteVarLong(v int64) []byte {
+	return WriteUVarLong(EncodeZigZag64(v))
+}
+
+// AppendUVarInt appends an unsigned varint to dst and returns the extended buffer
+func AppendUVarInt(dst []byte, v uint32) []byte {
+	for v >= 0x80 {
+		dst = append(dst, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(dst, byte(v))
+}
+
+// AppendVarInt appends a signed varint to dst and returns the extended buffer
+func AppendVarInt(dst []byte, v int32) []byte {
+	return AppendUVarInt(dst, EncodeZigZag32(v))
+}
+
+// AppendUVarLong appends an unsigned varlong to dst and returns the extended buffer
+func AppendUVarLong(dst []byte, v uint64) []byte {
+	for v >= 0x80 {
+		dst = append(dst, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(dst, byte(v))
+}
+
+// AppendVarLong appends a signed varlong to dst and returns the extended buffer
+func AppendVarLong(dst []byte, v int64) []byte {
+	return AppendUVarLong(dst, EncodeZigZag64(v))
+}
+
+// EncodeZigZag32 encodes a signed int with ZigZag encoding
+func EncodeZigZag32(v int32) uint32 {
+	return uint32(v<<1) ^ uint32(v>>31)
+}
+
+// DecodeZigZag32 decodes a ZigZag encoded int
+func DecodeZigZag32(v uint32) int32 {
+	return int32(v>>1) ^ -int32(v&1)
+}
+
+// EncodeZigZag64 encodes a signed long with ZigZag encoding
+func EncodeZigZag64(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// DecodeZigZag64 decodes a ZigZag encoded long
+func DecodeZigZag64(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
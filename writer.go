@@ -0,0 +1,312 @@
+package binary
+
+/*
+	Binary
+
+	Copyright (c) 2018 beito
+
+	This software is released under the MIT License.
+	http://opensource.org/licenses/mit-license.php
+*/
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// NewWriter returns new Writer
+func NewWriter(w io.Writer) *Writer {
+	return NewWriterSize(w, DefaultBufferSize)
+}
+
+// NewWriterSize returns new Writer with the buffer size
+func NewWriterSize(w io.Writer, size int) *Writer {
+	return &Writer{
+		w: bufio.NewWriterSize(w, size),
+	}
+}
+
+// Writer is a binary writer with an io.Writer
+// It puts values like Stream, and writes them into the io.Writer when the buffer is full.
+// If an error occurs writing, every subsequent call returns the error
+type Writer struct {
+	w   *bufio.Writer
+	off int64
+	err error
+}
+
+// Err returns the first error which occurred writing
+func (w *Writer) Err() error {
+	return w.err
+}
+
+func (w *Writer) setErr(err error) error {
+	if w.err == nil {
+		w.err = err
+	}
+
+	return err
+}
+
+// Off returns count of written bytes, including buffered bytes
+func (w *Writer) Off() int64 {
+	return w.off
+}
+
+// Buffered returns count of bytes which are not written yet
+func (w *Writer) Buffered() int {
+	return w.w.Buffered()
+}
+
+// Flush writes buffered bytes into the io.Writer
+func (w *Writer) Flush() error {
+	return w.setErr(w.w.Flush())
+}
+
+// Write writes p
+func (w *Writer) Write(p []byte) (n int, err error) {
+	n, err = w.w.Write(p)
+	w.off += int64(n)
+
+	return n, w.setErr(err)
+}
+
+// Put puts value
+func (w *Writer) Put(value []byte) error {
+	_, err := w.Write(value)
+
+	return err
+}
+
+// Pad puts empty bytes (0x00) of le (len).
+func (w *Writer) Pad(le int) error {
+	for ; le > 0; le-- {
+		err := w.w.WriteByte(0x00)
+		if err != nil {
+			return w.setErr(err)
+		}
+
+		w.off++
+	}
+
+	return nil
+}
+
+// PutByte puts an unsigned byte
+func (w *Writer) PutByte(value byte) error {
+	return w.Put(AppendByte(w.w.AvailableBuffer(), value))
+}
+
+// PutSByte puts a signed byte
+func (w *Writer) PutSByte(value int8) error {
+	return w.Put(AppendSByte(w.w.AvailableBuffer(), value))
+}
+
+// PutShort puts an unsigned short
+func (w *Writer) PutShort(value uint16) error {
+	return w.Put(AppendUShort(w.w.AvailableBuffer(), value))
+}
+
+// PutSShort puts a signed short
+func (w *Writer) PutSShort(value int16) error {
+	return w.Put(AppendShort(w.w.AvailableBuffer(), value))
+}
+
+// PutLShort puts an unsigned short with LittleEndian
+func (w *Writer) PutLShort(value uint16) error {
+	return w.Put(AppendLUShort(w.w.AvailableBuffer(), value))
+}
+
+// PutLSShort puts a signed short with LittleEndian
+func (w *Writer) PutLSShort(value int16) error {
+	return w.Put(AppendLShort(w.w.AvailableBuffer(), value))
+}
+
+// PutTriad puts a signed triad
+func (w *Writer) PutTriad(value int32) error {
+	return w.Put(AppendTriad(w.w.AvailableBuffer(), value))
+}
+
+// PutUTriad puts an unsigned triad
+func (w *Writer) PutUTriad(value uint32) error {
+	return w.Put(AppendUTriad(w.w.AvailableBuffer(), value))
+}
+
+// PutLTriad puts a signed triad with LittleEndian
+func (w *Writer) PutLTriad(value int32) error {
+	return w.Put(AppendLTriad(w.w.AvailableBuffer(), value))
+}
+
+// PutLUTriad puts an unsigned triad with LittleEndian
+func (w *Writer) PutLUTriad(value uint32) error {
+	return w.Put(AppendLUTriad(w.w.AvailableBuffer(), value))
+}
+
+// PutInt puts a signed int
+func (w *Writer) PutInt(value int32) error {
+	return w.Put(AppendInt(w.w.AvailableBuffer(), value))
+}
+
+// PutUInt puts an unsigned int
+func (w *Writer) PutUInt(value uint32) error {
+	return w.Put(AppendUInt(w.w.AvailableBuffer(), value))
+}
+
+// PutLInt puts a signed int with LittleEndian
+func (w *Writer) PutLInt(value int32) error {
+	return w.Put(AppendLInt(w.w.AvailableBuffer(), value))
+}
+
+// PutLUInt puts an unsigned int with LittleEndian
+func (w *Writer) PutLUInt(value uint32) error {
+	return w.Put(AppendLUInt(w.w.AvailableBuffer(), value))
+}
+
+// PutLong puts a signed long
+func (w *Writer) PutLong(value int64) error {
+	return w.Put(AppendLong(w.w.AvailableBuffer(), value))
+}
+
+// PutLLong puts a signed long with LittleEndian
+func (w *Writer) PutLLong(value int64) error {
+	return w.Put(AppendLLong(w.w.AvailableBuffer(), value))
+}
+
+// PutULong puts an unsigned long
+func (w *Writer) PutULong(value uint64) error {
+	return w.Put(AppendULong(w.w.AvailableBuffer(), value))
+}
+
+// PutLULong puts an unsigned long with LittleEndian
+func (w *Writer) PutLULong(value uint64) error {
+	return w.Put(AppendLULong(w.w.AvailableBuffer(), value))
+}
+
+// PutFloat puts a float
+func (w *Writer) PutFloat(value float32) error {
+	return w.Put(AppendFloat(w.w.AvailableBuffer(), value))
+}
+
+// PutLFloat puts a float with LittleEndian
+func (w *Writer) PutLFloat(value float32) error {
+	return w.Put(AppendLFloat(w.w.AvailableBuffer(), value))
+}
+
+// PutDouble puts a double
+func (w *Writer) PutDouble(value float64) error {
+	return w.Put(AppendDouble(w.w.AvailableBuffer(), value))
+}
+
+// PutLDouble puts a double with LittleEndian
+func (w *Writer) PutLDouble(value float64) error {
+	return w.Put(AppendLDouble(w.w.AvailableBuffer(), value))
+}
+
+// PutBool puts a byte as bool
+func (w *Writer) PutBool(value bool) error {
+	var val byte
+	if value {
+		val = 1 // true
+	}
+
+	return w.PutByte(val)
+}
+
+// PutVarInt puts a signed varint (ZigZag encoded)
+func (w *Writer) PutVarInt(value int32) error {
+	return w.Put(AppendVarInt(w.w.AvailableBuffer(), value))
+}
+
+// PutUVarInt puts an unsigned varint
+func (w *Writer) PutUVarInt(value uint32) error {
+	return w.Put(AppendUVarInt(w.w.AvailableBuffer(), value))
+}
+
+// PutVarLong puts a signed varlong (ZigZag encoded)
+func (w *Writer) PutVarLong(value int64) error {
+	return w.Put(AppendVarLong(w.w.AvailableBuffer(), value))
+}
+
+// PutUVarLong puts an unsigned varlong
+func (w *Writer) PutUVarLong(value uint64) error {
+	return w.Put(AppendUVarLong(w.w.AvailableBuffer(), value))
+}
+
+// PutLength puts a length with the prefix
+func (w *Writer) PutLength(prefix Prefix, ln int) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	b, err := AppendLength(w.w.AvailableBuffer(), prefix, ln)
+	if err != nil {
+		return err
+	}
+
+	return w.Put(b)
+}
+
+// PutByteArray puts bytes with a length prefix
+func (w *Writer) PutByteArray(prefix Prefix, value []byte) error {
+	err := w.PutLength(prefix, len(value))
+	if err != nil {
+		return err
+	}
+
+	return w.Put(value)
+}
+
+// putString puts a string without converting to bytes
+func (w *Writer) putString(value string) error {
+	n, err := w.w.WriteString(value)
+	w.off += int64(n)
+
+	return w.setErr(err)
+}
+
+// PutString puts a string with a length prefix
+func (w *Writer) PutString(prefix Prefix, value string) error {
+	err := w.PutLength(prefix, len(value))
+	if err != nil {
+		return err
+	}
+
+	return w.putString(value)
+}
+
+// PutCString puts a null terminated string
+func (w *Writer) PutCString(value string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if strings.IndexByte(value, 0x00) >= 0 {
+		return ErrNullChar
+	}
+
+	err := w.putString(value)
+	if err != nil {
+		return err
+	}
+
+	return w.PutByte(0x00)
+}
+
+// PutFixedString puts a string padded with 0x00 to n bytes
+func (w *Writer) PutFixedString(n int, value string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if len(value) > n {
+		return ErrTooLong
+	}
+
+	err := w.putString(value)
+	if err != nil {
+		return err
+	}
+
+	return w.Pad(n - len(value))
+}
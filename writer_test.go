@@ -0,0 +1,95 @@
+package binary
+
+/*
+ * Binary
+ *
+ * Copyright (c) 2018 beito
+ *
+ * This software is released under the MIT License.
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// errWriter is an io.Writer which fails after n bytes
+type errWriter struct {
+	n   int
+	err error
+}
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+
+		return n, w.err
+	}
+
+	w.n -= len(p)
+
+	return len(p), nil
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer := NewWriterSize(&buf, 16)
+	writer.PutInt(-1)
+	writer.PutLFloat(1.5)
+	writer.PutVarLong(-300)
+	writer.PutString(PrefixShort, "abcdefghijklmnopqrstuvwxyz") // over the buffer
+	writer.PutLUTriad(0x010203)
+
+	if writer.Buffered() == 0 {
+		t.Fatalf("Expected buffered bytes, but %d", writer.Buffered())
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Failed to flush Error: %s", err)
+	}
+
+	stream := NewStream()
+	stream.PutInt(-1)
+	stream.PutLFloat(1.5)
+	stream.PutVarLong(-300)
+	stream.PutString(PrefixShort, "abcdefghijklmnopqrstuvwxyz")
+	stream.PutLUTriad(0x010203)
+
+	exp := stream.Bytes()
+	ret := buf.Bytes()
+	if !bytes.Equal(ret, exp) {
+		t.Fatalf("Expected %d for bytes, but %d", exp, ret)
+	}
+
+	if off := writer.Off(); off != int64(len(exp)) {
+		t.Fatalf("Expected %d for offset, but %d", len(exp), off)
+	}
+}
+
+func TestWriterError(t *testing.T) {
+	errTest := errors.New("test error")
+
+	writer := NewWriterSize(&errWriter{n: 4, err: errTest}, 16)
+	writer.PutLong(1)
+	writer.PutLong(2)
+
+	if err := writer.PutLong(3); err != errTest {
+		t.Fatalf("Expected %v for put, but %v", errTest, err)
+	}
+
+	if err := writer.PutFixedString(2, "abc"); err != errTest {
+		t.Fatalf("Expected %v for put, but %v", errTest, err)
+	}
+
+	if err := writer.Flush(); err != errTest {
+		t.Fatalf("Expected %v for flush, but %v", errTest, err)
+	}
+
+	if err := writer.Err(); err != errTest {
+		t.Fatalf("Expected %v for error, but %v", errTest, err)
+	}
+}